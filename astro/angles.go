@@ -0,0 +1,83 @@
+package astro
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Right ascension of the meridian (local apparent sidereal time), arc-degrees,
+// and true obliquity of the ecliptic for jd, Standard Julian Date, given
+// geographical longitude lng, negative westwards.
+func ramc(jd, lng float64) (ra, eps float64) {
+	nut := core.ComputeNutation(jd)
+	return nut.SiderealTime(jd, lng) * 15, nut.TrueObliquity(jd)
+}
+
+// Ascendant, the point of the ecliptic rising on the eastern horizon, given ra,
+// right ascension of the meridian, eps, obliquity of the ecliptic, and lat,
+// geographical latitude.
+func ascendant(ra, eps, lat float64) float64 {
+	sinr, cosr := math.Sincos(mathutils.Radians(ra))
+	sine, cose := math.Sincos(mathutils.Radians(eps))
+	tanp := math.Tan(mathutils.Radians(lat))
+	return mathutils.ReduceDeg(mathutils.Degrees(math.Atan2(cosr, -(sine*tanp + cose*sinr))))
+}
+
+// Midheaven, the point of the ecliptic on the upper meridian, given ra, right ascension
+// of the meridian, and eps, obliquity of the ecliptic.
+func midheaven(ra, eps float64) float64 {
+	return eclipticPoint(ra, eps)
+}
+
+// Ecliptic longitude of the point of the ecliptic with right ascension ra, given eps,
+// obliquity of the ecliptic. Both points lie in the same quadrant.
+func eclipticPoint(ra, eps float64) float64 {
+	sinr, cosr := math.Sincos(mathutils.Radians(ra))
+	return mathutils.ReduceDeg(mathutils.Degrees(math.Atan2(sinr, cosr*math.Cos(mathutils.Radians(eps)))))
+}
+
+// Ascendant, ecliptic longitude of the point of the ecliptic rising on the eastern
+// horizon, arc-degrees, for jd, Standard Julian Date, given geographical longitude
+// (negative westwards) and latitude of the observer. The longitude refers
+// to the true equinox of date.
+//
+// At the polar circles the ecliptic may coincide with the horizon and
+// the ascendant is not defined.
+func Ascendant(jd, lng, lat float64) float64 {
+	ra, eps := ramc(jd, lng)
+	return ascendant(ra, eps, lat)
+}
+
+// Same as [Ascendant] for an observer.
+func AscendantForObserver(jd float64, obs core.Observer) float64 {
+	return Ascendant(jd, obs.Longitude, obs.Latitude)
+}
+
+// Midheaven (Medium Coeli, MC), ecliptic longitude of the point of the ecliptic
+// culminating on the upper meridian, arc-degrees, for jd, Standard Julian Date, given
+// geographical longitude of the observer, negative westwards. The longitude refers
+// to the true equinox of date. Unlike the Ascendant, it does not depend on the latitude.
+func Midheaven(jd, lng float64) float64 {
+	ra, eps := ramc(jd, lng)
+	return midheaven(ra, eps)
+}
+
+// Vertex, ecliptic longitude of the western intersection of the ecliptic with the prime
+// vertical, the great circle passing through the zenith and the East and West points,
+// arc-degrees, for jd, Standard Julian Date, given geographical longitude (negative
+// westwards) and latitude of the observer. The longitude refers to the true equinox
+// of date. The opposite, eastern intersection is called Antivertex.
+//
+// Vertex is found as the Ascendant for the lower meridian at co-latitude 90 - lat.
+func Vertex(jd, lng, lat float64) float64 {
+	ra, eps := ramc(jd, lng)
+	v := ascendant(ra+180, eps, 90-lat)
+	// make sure that the point is in the western half of the sky
+	equ := core.EclipticToEquatorial(core.EclipticPosition{Lambda: v}, eps)
+	if math.Sin(mathutils.Radians(ra-equ.Alpha)) < 0 {
+		v = mathutils.ReduceDeg(v + 180)
+	}
+	return v
+}
@@ -0,0 +1,61 @@
+package astro
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Greenwich Observatory
+const (
+	_GREENWICH_LNG = 0.0
+	_GREENWICH_LAT = 51.4769
+)
+
+func TestAscendant(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 5, Day: 1.5})
+	for _, lat := range []float64{-40, 0, _GREENWICH_LAT} {
+		got := Ascendant(jd, _GREENWICH_LNG, lat)
+		// the ascendant lies on the eastern horizon
+		ra, eps := ramc(jd, _GREENWICH_LNG)
+		equ := core.EclipticToEquatorial(core.EclipticPosition{Lambda: got}, eps)
+		hor := core.EquatorialToHorizontal(ra-equ.Alpha, equ.Delta, lat)
+		if !mathutils.AlmostEqual(hor.Altitude, 0, 1e-9) {
+			t.Errorf("Latitude %f, expected altitude: 0, got: %f", lat, hor.Altitude)
+		}
+		if hor.Azimuth <= 0 || hor.Azimuth >= 180 {
+			t.Errorf("Latitude %f, expected eastern azimuth, got: %f", lat, hor.Azimuth)
+		}
+	}
+}
+
+func TestMidheaven(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 5, Day: 1.5})
+	ra, eps := ramc(jd, _GREENWICH_LNG)
+	mc := core.EclipticToEquatorial(core.EclipticPosition{Lambda: Midheaven(jd, _GREENWICH_LNG)}, eps)
+	if d := mathutils.ReduceDeg(mc.Alpha-ra+180) - 180; !mathutils.AlmostEqual(d, 0, 1e-9) {
+		t.Errorf("Expected MC on the meridian, got hour angle: %f", d)
+	}
+	// at the equator the horizon passes through the celestial poles,
+	// so that the Ascendant is 90 degrees of right ascension east of the MC
+	asc := core.EclipticToEquatorial(core.EclipticPosition{Lambda: Ascendant(jd, _GREENWICH_LNG, 0)}, eps)
+	if d := mathutils.ReduceDeg(asc.Alpha - mc.Alpha); !mathutils.AlmostEqual(d, 90, 1e-9) {
+		t.Errorf("Expected: 90, got: %f", d)
+	}
+}
+
+func TestVertex(t *testing.T) {
+	for _, lat := range []float64{-40, 10, _GREENWICH_LAT, 60} {
+		for _, hour := range []float64{0, 5, 12, 19} {
+			jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 5, Day: 1 + hour/24})
+			ra, eps := ramc(jd, _GREENWICH_LNG)
+			equ := core.EclipticToEquatorial(core.EclipticPosition{Lambda: Vertex(jd, _GREENWICH_LNG, lat)}, eps)
+			hor := core.EquatorialToHorizontal(ra-equ.Alpha, equ.Delta, lat)
+			if !mathutils.AlmostEqual(hor.Azimuth, 270, 1e-6) {
+				t.Errorf("Latitude %f, hour %f, expected azimuth: 270, got: %f", lat, hour, hor.Azimuth)
+			}
+		}
+	}
+}
@@ -0,0 +1,24 @@
+// Calculations used in astrology: chart angles, houses, midpoints and parts.
+//
+// All angles are in arc-degrees, ecliptic longitudes are in range 0..360.
+package astro
+
+import "github.com/skrushinsky/scaliger/mathutils"
+
+// Midpoint of two ecliptic longitudes, arc-degrees, in range 0..360.
+//
+// The midpoint lies on the shorter arc between the longitudes, so that the
+// midpoint of 350 and 10 is 0, not 180. The result does not depend on the order
+// of arguments. If the longitudes are exactly opposite, both midpoints are equally
+// valid, and the one 90 degrees ahead of the smaller longitude is returned.
+func Midpoint(lon1, lon2 float64) float64 {
+	a := mathutils.ReduceDeg(lon1)
+	b := mathutils.ReduceDeg(lon2)
+	if a > b {
+		a, b = b, a
+	}
+	if b-a > 180 {
+		b -= 360
+	}
+	return mathutils.ReduceDeg((a + b) / 2)
+}
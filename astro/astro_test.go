@@ -0,0 +1,27 @@
+package astro
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestMidpoint(t *testing.T) {
+	cases := [...]struct {
+		lon1, lon2, exp float64
+	}{
+		{lon1: 350, lon2: 10, exp: 0},
+		{lon1: 10, lon2: 350, exp: 0},
+		{lon1: 10, lon2: 50, exp: 30},
+		{lon1: 300, lon2: 40, exp: 350},
+		{lon1: 120, lon2: 120, exp: 120},
+		{lon1: 0, lon2: 180, exp: 90},
+		{lon1: 180, lon2: 0, exp: 90},
+		{lon1: -10, lon2: 370, exp: 0},
+	}
+	for _, test := range cases {
+		if got := Midpoint(test.lon1, test.lon2); !mathutils.AlmostEqual(got, test.exp, 1e-9) {
+			t.Errorf("Midpoint of %f and %f, expected: %f, got: %f", test.lon1, test.lon2, test.exp, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package astro
+
+import (
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Definition of the sidereal zodiac, see [Ayanamsa].
+type AyanamsaMode int
+
+const (
+	// Lahiri (Chitrapaksha), official in India: 23°51'25.53" at J2000.0
+	Lahiri AyanamsaMode = iota
+	// Fagan-Bradley, used by western siderealists: 24°02'31.36" at B1950.0
+	FaganBradley
+)
+
+func (m AyanamsaMode) String() string {
+	switch m {
+	case Lahiri:
+		return "Lahiri"
+	case FaganBradley:
+		return "Fagan-Bradley"
+	default:
+		return "Unknown"
+	}
+}
+
+// Reference epoch, Standard Julian Date, and the ayanamsa at this epoch, arc-degrees.
+func (m AyanamsaMode) reference() (jd0, value float64) {
+	if m == FaganBradley {
+		return core.BesselianToJulian(1950), 24.042044
+	}
+	return julian.J2000, 23.857092
+}
+
+// General precession in longitude since J2000, arc-degrees, for jd, Standard Julian Date
+// (IAU 1976, Meeus, 21.5).
+func precession(jd float64) float64 {
+	t := (jd - julian.J2000) / julian.DAYS_PER_CENT
+	return mathutils.Polynome(t, 0, 5029.0966, 1.11113, -0.000006) / 3600
+}
+
+// Ayanamsa, the distance, arc-degrees, between the vernal equinox of date and
+// the origin of the sidereal zodiac, for jd, Standard Julian Date.
+//
+// The value at the reference epoch of the mode is increased by the general precession
+// in longitude, about 50.3" a year, so that the Lahiri ayanamsa is about 24.2 in 2024.
+// Nutation is not included, so the result refers to the mean equinox of date.
+func Ayanamsa(jd float64, mode AyanamsaMode) float64 {
+	jd0, value := mode.reference()
+	return value + precession(jd) - precession(jd0)
+}
+
+// Sidereal ecliptic longitude, arc-degrees, in range 0..360, given tropicalLon,
+// ecliptic longitude measured from the mean equinox of jd, Standard Julian Date.
+// See [Ayanamsa].
+func SiderealLongitude(tropicalLon, jd float64, mode AyanamsaMode) float64 {
+	return mathutils.ReduceDeg(tropicalLon - Ayanamsa(jd, mode))
+}
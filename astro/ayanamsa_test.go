@@ -0,0 +1,33 @@
+package astro
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestAyanamsa(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	if got := Ayanamsa(jd, Lahiri); !mathutils.AlmostEqual(got, 24.19, 0.01) {
+		t.Errorf("Expected: %f, got: %f", 24.19, got)
+	}
+	if got := Ayanamsa(julian.J2000, Lahiri); !mathutils.AlmostEqual(got, 23.857092, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 23.857092, got)
+	}
+	if got := Ayanamsa(julian.J2000, FaganBradley); !mathutils.AlmostEqual(got, 24.740, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", 24.740, got)
+	}
+}
+
+func TestSiderealLongitude(t *testing.T) {
+	// vernal equinox is in the last degrees of sidereal Pisces
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 20})
+	exp := 360 - Ayanamsa(jd, Lahiri)
+	if got := SiderealLongitude(0, jd, Lahiri); !mathutils.AlmostEqual(got, exp, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+	if got := SiderealLongitude(30, jd, Lahiri); !mathutils.AlmostEqual(got, exp-330, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", exp-330, got)
+	}
+}
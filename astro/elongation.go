@@ -0,0 +1,21 @@
+package astro
+
+import (
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/kepler/sun"
+)
+
+// Elongation, apparent geocentric angular distance of a body from the Sun, arc-degrees,
+// in range 0..180, for jd, Standard Julian Date. Elongation of the Sun itself is 0.
+//
+// Unlike the difference of longitudes, it accounts for the body's latitude, so that
+// at Full Moon the elongation is less than 180 degrees by up to 5 degrees,
+// unless there is a lunar eclipse.
+func Elongation(jd float64, body core.Body) float64 {
+	if body != core.Moon {
+		return 0
+	}
+	nut := core.ComputeNutation(jd)
+	return core.AngularSeparation(moon.EquatorialWithNutation(jd, nut), sun.EquatorialWithNutation(jd, nut))
+}
@@ -0,0 +1,24 @@
+package astro
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/scaliger/julian"
+)
+
+func TestElongation(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	full := moon.NextPhase(jd, moon.FullMoon)
+	if got := Elongation(full, core.Moon); got < 175 || got > 180 {
+		t.Errorf("Expected about 180, got: %f", got)
+	}
+	newMoon := moon.NextPhase(jd, moon.NewMoon)
+	if got := Elongation(newMoon, core.Moon); got < 0 || got > 5.5 {
+		t.Errorf("Expected about 0, got: %f", got)
+	}
+	if got := Elongation(jd, core.Sun); got != 0 {
+		t.Errorf("Expected: 0, got: %f", got)
+	}
+}
@@ -0,0 +1,111 @@
+package astro
+
+import (
+	"errors"
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Cusps of the Equal houses, ecliptic longitudes in range 0..360, given ascendant,
+// ecliptic longitude of the ascendant, arc-degrees. The first cusp is the ascendant,
+// each next one is 30 degrees further along the ecliptic. See [Ascendant].
+func HousesEqual(ascendant float64) [12]float64 {
+	var res [12]float64
+	for i := range res {
+		res[i] = mathutils.ReduceDeg(ascendant + float64(i)*30)
+	}
+	return res
+}
+
+// Cusps of the Whole Sign houses, ecliptic longitudes in range 0..360, given ascendant,
+// ecliptic longitude of the ascendant, arc-degrees. The first house occupies
+// the whole zodiac sign containing the ascendant, so that the cusps are
+// the beginnings of the signs. See [Ascendant].
+func HousesWholeSign(ascendant float64) [12]float64 {
+	return HousesEqual(math.Floor(mathutils.ReduceDeg(ascendant)/30) * 30)
+}
+
+// Returned when the house system is not defined at the observer's latitude.
+var ErrPolarCircle = errors.New("houses are not defined within the polar circles")
+
+// Maximal number of iterations and desired precision of house cusps, arc-degrees.
+const (
+	_MAX_ITER = 50
+	_CUSP_EPS = 1e-9
+)
+
+// Finds the point of the ecliptic which has passed a given fraction of its semi-arc,
+// given ra, right ascension of the meridian, eps, obliquity of the ecliptic and
+// lat, geographical latitude. Right ascension of the point is ra + offset + k * SDA,
+// where SDA is its diurnal semi-arc. Since the semi-arc depends on the declination
+// of the point, the solution is found by iterations.
+func placidusCusp(ra, eps, lat, offset, k float64) (float64, error) {
+	sine := math.Sin(mathutils.Radians(eps))
+	tanp := math.Tan(mathutils.Radians(lat))
+	lambda := eclipticPoint(ra+offset+k*90, eps)
+	for i := 0; i < _MAX_ITER; i++ {
+		delta := math.Asin(sine * math.Sin(mathutils.Radians(lambda)))
+		x := -tanp * math.Tan(delta)
+		if x < -1 || x > 1 {
+			return 0, ErrPolarCircle
+		}
+		sda := mathutils.Degrees(math.Acos(x))
+		next := eclipticPoint(ra+offset+k*sda, eps)
+		d := mathutils.ReduceDeg(next-lambda+180) - 180
+		lambda = next
+		if math.Abs(d) < _CUSP_EPS {
+			break
+		}
+	}
+	return lambda, nil
+}
+
+// Cusps of the Placidus houses, ecliptic longitudes in range 0..360, for jd,
+// Standard Julian Date, given geographical longitude (negative westwards) and
+// latitude of the observer, arc-degrees.
+//
+// The 1st, 4th, 7th and 10th cusps are the Ascendant, the lower meridian (IC),
+// the Descendant and the Midheaven. Intermediate cusps trisect the time a point
+// of the ecliptic needs to move along its diurnal or nocturnal semi-arc: e.g.
+// the 11th cusp has passed one third of its diurnal semi-arc from the meridian
+// to the eastern horizon, the 12th cusp two thirds. Within the polar circles some
+// points of the ecliptic never rise or set and the system is not defined,
+// in this case [ErrPolarCircle] is returned.
+func HousesPlacidus(jd, lng, lat float64) ([12]float64, error) {
+	var res [12]float64
+	ra, eps := ramc(jd, lng)
+	if math.Abs(lat) >= 90-eps {
+		return res, ErrPolarCircle
+	}
+	// indices of cusps, offsets of their right ascensions from RAMC and fractions
+	// of the diurnal semi-arc; nocturnal semi-arc is 180 - SDA, so that e.g. the 2nd cusp
+	// is at RAMC + SDA + (180 - SDA) / 3
+	for _, c := range [...]struct {
+		i         int
+		offset, k float64
+	}{
+		{i: 10, offset: 0, k: 1.0 / 3},
+		{i: 11, offset: 0, k: 2.0 / 3},
+		{i: 1, offset: 60, k: 2.0 / 3},
+		{i: 2, offset: 120, k: 1.0 / 3},
+	} {
+		cusp, err := placidusCusp(ra, eps, lat, c.offset, c.k)
+		if err != nil {
+			return res, err
+		}
+		res[c.i] = cusp
+		res[(c.i+6)%12] = mathutils.ReduceDeg(cusp + 180)
+	}
+	res[0] = ascendant(ra, eps, lat)
+	res[6] = mathutils.ReduceDeg(res[0] + 180)
+	res[9] = midheaven(ra, eps)
+	res[3] = mathutils.ReduceDeg(res[9] + 180)
+	return res, nil
+}
+
+// Same as [HousesPlacidus] for an observer.
+func HousesPlacidusForObserver(jd float64, obs core.Observer) ([12]float64, error) {
+	return HousesPlacidus(jd, obs.Longitude, obs.Latitude)
+}
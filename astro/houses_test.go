@@ -0,0 +1,82 @@
+package astro
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestHousesEqual(t *testing.T) {
+	got := HousesEqual(345.5)
+	if got[0] != 345.5 {
+		t.Errorf("Expected: %f, got: %f", 345.5, got[0])
+	}
+	for i := range got {
+		next := got[(i+1)%12]
+		if d := mathutils.ReduceDeg(next - got[i]); !mathutils.AlmostEqual(d, 30, 1e-9) {
+			t.Errorf("Cusp %d, expected 30 degrees to the next one, got: %f", i+1, d)
+		}
+	}
+}
+
+func TestHousesWholeSign(t *testing.T) {
+	got := HousesWholeSign(345.5)
+	for i, exp := range [...]float64{330, 0, 30, 60, 90, 120, 150, 180, 210, 240, 270, 300} {
+		if !mathutils.AlmostEqual(got[i], exp, 1e-9) {
+			t.Errorf("Cusp %d, expected: %f, got: %f", i+1, exp, got[i])
+		}
+	}
+}
+
+func TestHousesPlacidus(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 5, Day: 1.5})
+	for _, lat := range []float64{-40, 0, _GREENWICH_LAT, 66} {
+		got, err := HousesPlacidus(jd, _GREENWICH_LNG, lat)
+		if err != nil {
+			t.Fatalf("Latitude %f, unexpected error: %v", lat, err)
+		}
+		ra, eps := ramc(jd, _GREENWICH_LNG)
+		asc := Ascendant(jd, _GREENWICH_LNG, lat)
+		mc := midheaven(ra, eps)
+		for i, exp := range map[int]float64{
+			0: asc,
+			3: mathutils.ReduceDeg(mc + 180),
+			6: mathutils.ReduceDeg(asc + 180),
+			9: mc,
+		} {
+			if !mathutils.AlmostEqual(got[i], exp, 1e-9) {
+				t.Errorf("Latitude %f, cusp %d, expected: %f, got: %f", lat, i+1, exp, got[i])
+			}
+		}
+		// cusps follow in the order of the zodiac
+		for i := range got {
+			if d := mathutils.ReduceDeg(got[(i+1)%12] - got[i]); d <= 0 || d >= 90 {
+				t.Errorf("Latitude %f, unexpected distance between cusps %d and %d: %f", lat, i+1, i+2, d)
+			}
+		}
+	}
+	// at the equator the houses trisect the quadrants in right ascension
+	got, _ := HousesPlacidus(jd, _GREENWICH_LNG, 0)
+	ra, eps := ramc(jd, _GREENWICH_LNG)
+	if exp := eclipticPoint(ra+30, eps); !mathutils.AlmostEqual(got[10], exp, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", exp, got[10])
+	}
+	if _, err := HousesPlacidus(jd, _GREENWICH_LNG, 70); err != ErrPolarCircle {
+		t.Errorf("Expected ErrPolarCircle, got: %v", err)
+	}
+}
+
+func TestForObserver(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 5, Day: 1.5})
+	obs := core.Observer{Longitude: -74.006, Latitude: 40.7128, Elevation: 10}
+	if got, exp := AscendantForObserver(jd, obs), Ascendant(jd, obs.Longitude, obs.Latitude); got != exp {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+	got, err1 := HousesPlacidusForObserver(jd, obs)
+	exp, err2 := HousesPlacidus(jd, obs.Longitude, obs.Latitude)
+	if got != exp || err1 != err2 {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+}
@@ -0,0 +1,22 @@
+package astro
+
+import "github.com/skrushinsky/scaliger/mathutils"
+
+// Arabic part (lot), ecliptic longitude a + b - c, arc-degrees, in range 0..360.
+//
+// Traditionally a is the Ascendant, and b - c is the distance between two
+// significators, so that the part is as far from the Ascendant as b from c.
+func ArabicPart(a, b, c float64) float64 {
+	return mathutils.ReduceDeg(a + b - c)
+}
+
+// Part of Fortune, ecliptic longitude, arc-degrees, in range 0..360, given longitudes
+// of the Ascendant, the Sun and the Moon. For a day birth, when the Sun is above
+// the horizon, it is Ascendant + Moon - Sun, for a night birth the formula
+// is reversed: Ascendant + Sun - Moon. See [ArabicPart].
+func PartOfFortune(ascendant, sunLon, moonLon float64, dayBirth bool) float64 {
+	if dayBirth {
+		return ArabicPart(ascendant, moonLon, sunLon)
+	}
+	return ArabicPart(ascendant, sunLon, moonLon)
+}
@@ -0,0 +1,35 @@
+package astro
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestPartOfFortune(t *testing.T) {
+	// Ascendant 10 Aries, Sun 15 Leo, Moon 20 Capricorn
+	asc, sun, moon := 10.0, 135.0, 290.0
+	// day: 10 + 290 - 135 = 165, 15 Virgo
+	if got := PartOfFortune(asc, sun, moon, true); !mathutils.AlmostEqual(got, 165, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", 165.0, got)
+	}
+	// night: 10 + 135 - 290 = -145, i.e. 215, 5 Scorpio
+	if got := PartOfFortune(asc, sun, moon, false); !mathutils.AlmostEqual(got, 215, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", 215.0, got)
+	}
+}
+
+func TestArabicPart(t *testing.T) {
+	cases := [...]struct {
+		a, b, c, exp float64
+	}{
+		{a: 350, b: 30, c: 10, exp: 10},
+		{a: 0, b: 10, c: 350, exp: 20},
+		{a: 100, b: 50, c: 50, exp: 100},
+	}
+	for _, c := range cases {
+		if got := ArabicPart(c.a, c.b, c.c); !mathutils.AlmostEqual(got, c.exp, 1e-9) {
+			t.Errorf("Expected: %f, got: %f", c.exp, got)
+		}
+	}
+}
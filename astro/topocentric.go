@@ -0,0 +1,32 @@
+package astro
+
+import (
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/kepler/sun"
+)
+
+// Apparent ecliptic longitude of a body as seen from the observer's place, arc-degrees,
+// in range 0..360, for jd, Standard Julian Date, given geographical longitude
+// (negative westwards) and latitude of the observer at sea level. The longitude refers
+// to the true equinox of date.
+//
+// Geocentric position is corrected for parallax. Only the Moon, with horizontal parallax
+// of about 1 degree, is shifted enough to change the degree of the zodiac: the shift
+// is largest near the horizon and may reach 1 degree. Parallax of the Sun, 8.8", is
+// negligible for charts, the more so for planets.
+func TopocentricLongitude(jd, lng, lat float64, body core.Body) float64 {
+	nut := core.ComputeNutation(jd)
+	var equ core.EquatorialPosition
+	var parallax float64
+	if body == core.Moon {
+		equ = moon.EquatorialWithNutation(jd, nut)
+		parallax = moon.Parallax(jd)
+	} else {
+		equ = sun.EquatorialWithNutation(jd, nut)
+		parallax = 8.794 / 3600 / sun.Position(jd, core.PrecisionMedium).Delta
+	}
+	ra := nut.SiderealTime(jd, lng) * 15
+	topo := core.EquatorialToTopocentric(equ, parallax, ra-equ.Alpha, lat, 0)
+	return core.EquatorialToEcliptic(topo, nut.TrueObliquity(jd)).Lambda
+}
@@ -0,0 +1,34 @@
+package astro
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestTopocentricLongitude(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25})
+	rise, _, err := moon.RiseSet(jd, _GREENWICH_LNG, _GREENWICH_LAT, moon.UpperLimb)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, eps := ramc(rise, _GREENWICH_LNG)
+	// near the horizon the Moon is shifted by a large fraction of its parallax
+	geo := core.EquatorialToEcliptic(moon.Equatorial(rise), eps).Lambda
+	got := TopocentricLongitude(rise, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon)
+	d := math.Abs(mathutils.ReduceDeg(got-geo+180) - 180)
+	if p := moon.Parallax(rise); d < p/2 || d > p {
+		t.Errorf("Expected shift between %f and %f, got: %f", p/2, p, d)
+	}
+	// parallax of the Sun is below 9 arc-seconds
+	geo = core.EquatorialToEcliptic(sun.Equatorial(rise), eps).Lambda
+	got = TopocentricLongitude(rise, _GREENWICH_LNG, _GREENWICH_LAT, core.Sun)
+	if d := math.Abs(mathutils.ReduceDeg(got-geo+180)-180) * 3600; d > 9 {
+		t.Errorf("Expected shift below 9 arc-seconds, got: %f", d)
+	}
+}
@@ -0,0 +1,32 @@
+// Physical and astronomical constants shared by the library.
+//
+// Sources: IAU 2012 Resolution B2 (astronomical unit), IAU 2015 Resolution B3
+// (nominal solar radius), WGS 84 and IUGG (Earth's figure), JPL DE430 (Earth/Moon mass ratio).
+package constants
+
+// Astronomical unit, km
+const AU = 149597870.7
+
+// Speed of light in vacuum, km/s
+const LIGHT_SPEED = 299792.458
+
+// Light time for one astronomical unit, seconds
+const LIGHT_TIME_AU = AU / LIGHT_SPEED
+
+// Equatorial radius of the Earth, km
+const EARTH_RADIUS = 6378.137
+
+// Mean radius of the Earth (IUGG), km
+const EARTH_MEAN_RADIUS = 6371.0088
+
+// Flattening of the Earth
+const EARTH_FLATTENING = 1 / 298.257223563
+
+// Mean radius of the Moon, km
+const MOON_RADIUS = 1737.4
+
+// Nominal radius of the Sun, km
+const SUN_RADIUS = 695700.0
+
+// Ratio of the Earth's mass to the Moon's mass
+const EARTH_MOON_MASS_RATIO = 81.30056907419062
@@ -0,0 +1,18 @@
+package constants
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestLightTime(t *testing.T) {
+	got := AU / LIGHT_SPEED
+	exp := 499.004784
+	if !mathutils.AlmostEqual(got, exp, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+	if !mathutils.AlmostEqual(LIGHT_TIME_AU, got, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", got, LIGHT_TIME_AU)
+	}
+}
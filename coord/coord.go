@@ -0,0 +1,25 @@
+// Corrections which relate coordinate systems of different epochs and equinoxes.
+package coord
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Equation of the equinoxes, arc-degrees, for jd, Standard Julian Date:
+// nutation in longitude projected on the equator, dpsi * cos(eps),
+// where eps is the true obliquity of the ecliptic.
+func EquationOfEquinoxesDeg(jd float64) float64 {
+	nut := core.ComputeNutation(jd)
+	return nut.Dpsi * math.Cos(mathutils.Radians(nut.TrueObliquity(jd)))
+}
+
+// Equation of the equinoxes, seconds of time, for jd, Standard Julian Date.
+//
+// It is the difference between apparent and mean sidereal time and never
+// exceeds about 1.2 seconds.
+func EquationOfEquinoxes(jd float64) float64 {
+	return EquationOfEquinoxesDeg(jd) * 240
+}
@@ -0,0 +1,105 @@
+package coord
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestEquationOfEquinoxes(t *testing.T) {
+	// Meeus, example 12.a, 1987 April 10, 0h UT: -0.2317 sec.
+	got := EquationOfEquinoxes(2446895.5)
+	if !mathutils.AlmostEqual(got, -0.2317, 0.05) {
+		t.Errorf("Expected: %f, got: %f", -0.2317, got)
+	}
+	deg := EquationOfEquinoxesDeg(2446895.5)
+	if !mathutils.AlmostEqual(deg*240, got, 1e-12) {
+		t.Errorf("Expected: %f, got: %f", got, deg*240)
+	}
+}
+
+func TestEquationOfEquinoxesRange(t *testing.T) {
+	// a full cycle of nutation, 18.6 years
+	for jd := julian.J2000; jd < julian.J2000+6800; jd += 10 {
+		if got := EquationOfEquinoxes(jd); math.Abs(got) > 1.2 {
+			t.Errorf("Expected value within 1.2 sec., got: %f", got)
+		}
+	}
+}
+
+func TestPrecess(t *testing.T) {
+	// Meeus, example 21.b: theta Persei from J2000 to 2028 Nov 13.19 TD
+	pos := core.EquatorialPosition{Alpha: 41.054063, Delta: 49.227750}
+	got := Precess(pos, julian.J2000, 2462088.69)
+	if !mathutils.AlmostEqual(got.Alpha, 41.547214, 1e-6) {
+		t.Errorf("Expected Alpha: %f, got: %f", 41.547214, got.Alpha)
+	}
+	if !mathutils.AlmostEqual(got.Delta, 49.348483, 1e-6) {
+		t.Errorf("Expected Delta: %f, got: %f", 49.348483, got.Delta)
+	}
+	back := Precess(got, 2462088.69, julian.J2000)
+	if !mathutils.AlmostEqual(back.Alpha, pos.Alpha, 1e-8) || !mathutils.AlmostEqual(back.Delta, pos.Delta, 1e-8) {
+		t.Errorf("Expected: %v, got: %v", pos, back)
+	}
+}
+
+func TestFK5ToFK4(t *testing.T) {
+	// quasar 3C 273: J2000 12h29m06.6997s +2°03'08.598", B1950 12h26m33.246s +2°19'43.29"
+	pos := core.EquatorialPosition{Alpha: 187.277915, Delta: 2.052388}
+	got := FK5ToFK4(pos)
+	exp := core.EquatorialPosition{Alpha: 186.638525, Delta: 2.328692}
+	if d := core.AngularSeparation(got, exp) * 3600; d > 0.5 {
+		t.Errorf("Expected: %v, got: %v, difference: %f\"", exp, got, d)
+	}
+}
+
+func TestEquatorialToGalactic(t *testing.T) {
+	// origin of galactic coordinates
+	l, b := EquatorialToGalactic(core.EquatorialPosition{Alpha: 266.404996, Delta: -28.936172})
+	if d := math.Min(l, 360-l); d > 1e-4 {
+		t.Errorf("Expected l: 0, got: %f", l)
+	}
+	if !mathutils.AlmostEqual(b, 0, 1e-4) {
+		t.Errorf("Expected b: 0, got: %f", b)
+	}
+	// North galactic pole
+	if _, b := EquatorialToGalactic(core.EquatorialPosition{Alpha: 192.85948, Delta: 27.12825}); !mathutils.AlmostEqual(b, 90, 1e-6) {
+		t.Errorf("Expected b: 90, got: %f", b)
+	}
+	// Vega, l = 67.448, b = 19.237
+	l, b = EquatorialToGalactic(core.EquatorialPosition{Alpha: 279.234735, Delta: 38.783689})
+	if !mathutils.AlmostEqual(l, 67.448, 1e-3) || !mathutils.AlmostEqual(b, 19.237, 1e-3) {
+		t.Errorf("Expected l: %f, b: %f, got: %f, %f", 67.448, 19.237, l, b)
+	}
+}
+
+func TestPrecessionRates(t *testing.T) {
+	// at the vernal equinox: m = 3.075s and n = 20.043"
+	ra, dec := PrecessionRates(0, 0)
+	if !mathutils.AlmostEqual(ra/15, 3.075, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", 3.075, ra/15)
+	}
+	if !mathutils.AlmostEqual(dec, 20.043, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", 20.043, dec)
+	}
+	// Meeus, example 21.a: Regulus, +3.208s and -17.71" per year
+	ra, dec = PrecessionRates(152.092917, 11.967222)
+	if !mathutils.AlmostEqual(ra/15, 3.208, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", 3.208, ra/15)
+	}
+	if !mathutils.AlmostEqual(dec, -17.71, 1e-2) {
+		t.Errorf("Expected: %f, got: %f", -17.71, dec)
+	}
+	// compare with the rigorous method over one year
+	pos := core.EquatorialPosition{Alpha: 152.092917, Delta: 11.967222}
+	got := Precess(pos, julian.J2000, julian.J2000+365.25)
+	if d := (got.Alpha - pos.Alpha) * 3600; !mathutils.AlmostEqual(d, ra, 0.01) {
+		t.Errorf("Expected: %f, got: %f", d, ra)
+	}
+	if d := (got.Delta - pos.Delta) * 3600; !mathutils.AlmostEqual(d, dec, 0.01) {
+		t.Errorf("Expected: %f, got: %f", d, dec)
+	}
+}
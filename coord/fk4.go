@@ -0,0 +1,45 @@
+package coord
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Rotation from FK5 J2000 to FK4 B1950 frame, positional part of the matrix of
+// Standish (1982), used in the Explanatory Supplement to the Astronomical Almanac.
+var _FK5_TO_FK4 = [3][3]float64{
+	{0.9999256795, 0.0111814828, 0.0048590039},
+	{-0.0111814828, 0.9999374849, -0.0000271771},
+	{-0.0048590040, -0.0000271557, 0.9999881946},
+}
+
+// E-terms of aberration, which are included in FK4 positions, radians
+var _E_TERMS = [3]float64{-1.62557e-6, -0.31919e-6, -0.13843e-6}
+
+// Converts mean equatorial position in FK5 system for equinox and epoch J2000
+// to FK4 system for equinox and epoch B1950, as used by old catalogs.
+//
+// The frames are rotated and the elliptic terms of aberration (E-terms), which
+// FK4 positions contain, are added. The body is assumed to have no proper motion
+// in FK5 system, which makes difference of a fraction of arc-second.
+func FK5ToFK4(pos core.EquatorialPosition) core.EquatorialPosition {
+	sina, cosa := math.Sincos(mathutils.Radians(pos.Alpha))
+	sind, cosd := math.Sincos(mathutils.Radians(pos.Delta))
+	r := [3]float64{cosd * cosa, cosd * sina, sind}
+	var v [3]float64
+	for i := range v {
+		v[i] = _FK5_TO_FK4[i][0]*r[0] + _FK5_TO_FK4[i][1]*r[1] + _FK5_TO_FK4[i][2]*r[2]
+	}
+	// add E-terms
+	dot := v[0]*_E_TERMS[0] + v[1]*_E_TERMS[1] + v[2]*_E_TERMS[2]
+	for i := range v {
+		v[i] += _E_TERMS[i] - dot*v[i]
+	}
+	x, y, z := v[0], v[1], v[2]
+	return core.EquatorialPosition{
+		Alpha: mathutils.ReduceDeg(mathutils.Degrees(math.Atan2(y, x))),
+		Delta: mathutils.Degrees(math.Atan2(z, math.Hypot(x, y))),
+	}
+}
@@ -0,0 +1,31 @@
+package coord
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Galactic frame in FK5 system J2000: equatorial coordinates of the North galactic pole
+// and galactic longitude of the North celestial pole, arc-degrees.
+const (
+	_GAL_POLE_RA  = 192.85948
+	_GAL_POLE_DEC = 27.12825
+	_GAL_NCP_LNG  = 122.93192
+)
+
+// Converts mean equatorial position for equinox J2000 to galactic
+// longitude l and latitude b, arc-degrees. The origin of galactic longitude
+// is close to the galactic center in Sagittarius.
+//
+// For positions referred to another equinox, see [Precess], for B1950 see [FK5ToFK4].
+func EquatorialToGalactic(pos core.EquatorialPosition) (l, b float64) {
+	sind, cosd := math.Sincos(mathutils.Radians(pos.Delta))
+	sinp, cosp := math.Sincos(mathutils.Radians(_GAL_POLE_DEC))
+	sina, cosa := math.Sincos(mathutils.Radians(pos.Alpha - _GAL_POLE_RA))
+	b = mathutils.Degrees(math.Asin(sind*sinp + cosd*cosp*cosa))
+	x := math.Atan2(cosd*sina, sind*cosp-cosd*sinp*cosa)
+	l = mathutils.ReduceDeg(_GAL_NCP_LNG - mathutils.Degrees(x))
+	return
+}
@@ -0,0 +1,56 @@
+package coord
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Precesses mean equatorial position from epoch jd0 to epoch jd, Standard Julian Dates,
+// using IAU 1976 (Lieske) precession angles in FK5 system.
+// Proper motion is not taken into account.
+//
+// Meeus, "Astronomical Algorithms", 21.2-21.4.
+func Precess(pos core.EquatorialPosition, jd0, jd float64) core.EquatorialPosition {
+	t := (jd0 - julian.J2000) / julian.DAYS_PER_CENT
+	tt := (jd - jd0) / julian.DAYS_PER_CENT
+	w := 2306.2181 + (1.39656-0.000139*t)*t
+	zeta := (w + ((0.30188-0.000344*t)+0.017998*tt)*tt) * tt
+	z := (w + ((1.09468+0.000066*t)+0.018203*tt)*tt) * tt
+	theta := ((2004.3109 + (-0.85330-0.000217*t)*t) - ((0.42665+0.000217*t)+0.041833*tt)*tt) * tt
+	zeta = mathutils.Radians(zeta / 3600)
+	z = mathutils.Radians(z / 3600)
+	theta = mathutils.Radians(theta / 3600)
+
+	sind, cosd := math.Sincos(mathutils.Radians(pos.Delta))
+	sina, cosa := math.Sincos(mathutils.Radians(pos.Alpha) + zeta)
+	sint, cost := math.Sincos(theta)
+	a := cosd * sina
+	b := cost*cosd*cosa - sint*sind
+	c := sint*cosd*cosa + cost*sind
+	return core.EquatorialPosition{
+		Alpha: mathutils.ReduceDeg(mathutils.Degrees(math.Atan2(a, b) + z)),
+		Delta: mathutils.Degrees(math.Asin(c)),
+	}
+}
+
+// Annual precession in right ascension, m, and in declination, n, arc-seconds per year,
+// for J2000, from the IAU 1976 angles of [Precess]: m = (zeta + z) / 100, n = theta / 100.
+const (
+	_PREC_M = (2306.2181 * 2) / 100
+	_PREC_N = 2004.3109 / 100
+)
+
+// Annual rates of precession in right ascension and declination, arc-seconds per year,
+// for mean equatorial position ra, dec, arc-degrees, near the epoch J2000.
+// The rate in right ascension is in arc-seconds too; divide it by 15 for seconds
+// of time. Close to the celestial poles the rate in right ascension
+// grows infinitely and the formula is not applicable.
+//
+// Meeus, "Astronomical Algorithms", 21.1: m + n sin(ra) tan(dec) and n cos(ra).
+func PrecessionRates(ra, dec float64) (dRAdt, dDecdt float64) {
+	sina, cosa := math.Sincos(mathutils.Radians(ra))
+	return _PREC_M + _PREC_N*sina*math.Tan(mathutils.Radians(dec)), _PREC_N * cosa
+}
@@ -0,0 +1,20 @@
+package core
+
+// Celestial body which position may be computed by the library.
+type Body int
+
+const (
+	Sun Body = iota
+	Moon
+)
+
+func (b Body) String() string {
+	switch b {
+	case Sun:
+		return "Sun"
+	case Moon:
+		return "Moon"
+	default:
+		return "Unknown"
+	}
+}
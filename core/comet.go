@@ -0,0 +1,78 @@
+package core
+
+import (
+	"math"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Precision of the universal anomaly and maximal number of Newton's iterations
+const (
+	_CHI_DELTA    = 1e-12
+	_CHI_MAX_ITER = 100
+)
+
+// Stumpff functions C(z) and S(z), which join elliptic (z > 0), parabolic (z = 0)
+// and hyperbolic (z < 0) cases of Kepler equation. Near zero the series are used.
+func stumpff(z float64) (c, s float64) {
+	switch {
+	case math.Abs(z) < 1e-3:
+		c = 1.0/2 - z/24 + z*z/720
+		s = 1.0/6 - z/120 + z*z/5040
+	case z > 0:
+		sz := math.Sqrt(z)
+		c = (1 - math.Cos(sz)) / z
+		s = (sz - math.Sin(sz)) / (sz * z)
+	default:
+		sz := math.Sqrt(-z)
+		c = (math.Cosh(sz) - 1) / -z
+		s = (math.Sinh(sz) - sz) / (sz * -z)
+	}
+	return
+}
+
+// Heliocentric ecliptic position of a comet for jd, Standard Julian Date, given
+// perihelion distance q, A.U., eccentricity e, inclination i, longitude of the ascending
+// node and argument of perihelion, arc-degrees, and tp, Standard Julian Date of the
+// perihelion passage, as JPL and MPC publish them. The result refers to the equinox
+// of the elements; distance is in A.U.
+//
+// Kepler equation is solved for the universal anomaly with Stumpff functions, so that
+// elliptic, parabolic and hyperbolic orbits are treated alike and there is no loss of
+// precision for eccentricities close to 1. Starting value is the solution of Barker's
+// equation for a parabola with the same perihelion distance.
+func CometPosition(q, e, i, node, argPeri, tp, jd float64) EclipticPosition {
+	t := jd - tp
+	alpha := (1 - e) / q // reciprocal of the semi-major axis
+	sqmu := GAUSS_K
+	// Barker's equation, chi^3 + 6q chi - 6 sqrt(mu) t = 0
+	p, r := 6*q, 3*sqmu*t
+	d := math.Sqrt(r*r + p*p*p/27)
+	chi := math.Cbrt(r+d) + math.Cbrt(r-d)
+	for k := 0; k < _CHI_MAX_ITER; k++ {
+		c, s := stumpff(alpha * chi * chi)
+		f := (1-alpha*q)*chi*chi*chi*s + q*chi - sqmu*t
+		df := (1-alpha*q)*chi*chi*c + q
+		dchi := f / df
+		chi -= dchi
+		if math.Abs(dchi) < _CHI_DELTA {
+			break
+		}
+	}
+	z := alpha * chi * chi
+	c, s := stumpff(z)
+	// Lagrange coefficients, starting from perihelion, where velocity is perpendicular
+	// to the radius vector
+	x := (1 - chi*chi/q*c) * q
+	y := (t - chi*chi*chi/sqmu*s) * sqmu * math.Sqrt((1+e)/q)
+	rv := math.Hypot(x, y)
+	u := math.Atan2(y, x) + mathutils.Radians(argPeri)
+	sinu, cosu := math.Sincos(u)
+	sinn, cosn := math.Sincos(mathutils.Radians(node))
+	sini, cosi := math.Sincos(mathutils.Radians(i))
+	return RectangularToSpherical(
+		rv*(cosn*cosu-sinn*sinu*cosi),
+		rv*(sinn*cosu+cosn*sinu*cosi),
+		rv*sinu*sini,
+	)
+}
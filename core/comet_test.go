@@ -0,0 +1,57 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestCometPositionParabolic(t *testing.T) {
+	// Meeus, "Astronomical Algorithms", chapter 35: q = 0.921326, e = 1, t - T = 138.4783 days,
+	// v = 102.74426, r = 2.364192
+	got := CometPosition(0.921326, 1, 0, 0, 0, 0, 138.4783)
+	if !mathutils.AlmostEqual(got.Lambda, 102.74426, 1e-5) {
+		t.Errorf("Expected: %f, got: %f", 102.74426, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Delta, 2.364192, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 2.364192, got.Delta)
+	}
+	// the result changes smoothly through e = 1
+	for _, e := range []float64{0.9999999, 1.0000001} {
+		p := CometPosition(0.921326, e, 0, 0, 0, 0, 138.4783)
+		if !mathutils.AlmostEqual(p.Lambda, got.Lambda, 1e-5) || !mathutils.AlmostEqual(p.Delta, got.Delta, 1e-6) {
+			t.Errorf("Expected: %v, got: %v for eccentricity %f", got, p, e)
+		}
+	}
+}
+
+func TestCometPositionElliptic(t *testing.T) {
+	// orbit of comet Encke, Meeus, example 33.a
+	q, e := 0.330905, 0.8502196
+	a := q / (1 - e)
+	n := GAUSS_K / math.Pow(a, 1.5)
+	for _, dt := range []float64{-22.04502, 100, 500, 3000} {
+		m := n * dt
+		ea := EccentricAnomaly(e, m, m)
+		v := mathutils.ReduceDeg(mathutils.Degrees(TrueAnomalyQuadrant(e, ea)))
+		r := a * (1 - e*math.Cos(ea))
+		got := CometPosition(q, e, 0, 0, 0, 0, dt)
+		if !mathutils.AlmostEqual(got.Lambda, v, 1e-5) {
+			t.Errorf("Expected: %f, got: %f", v, got.Lambda)
+		}
+		if !mathutils.AlmostEqual(got.Delta, r, 1e-6) {
+			t.Errorf("Expected: %f, got: %f", r, got.Delta)
+		}
+	}
+	// at perihelion the comet is at the perihelion point of the orbit
+	i, node, argPeri, tp := 11.94524, 334.75006, 186.23352, 2448192.54502
+	got := CometPosition(q, e, i, node, argPeri, tp, tp)
+	exp := mathutils.Degrees(math.Asin(math.Sin(mathutils.Radians(argPeri)) * math.Sin(mathutils.Radians(i))))
+	if !mathutils.AlmostEqual(got.Beta, exp, 1e-9) {
+		t.Errorf("Expected Beta: %f, got: %f", exp, got.Beta)
+	}
+	if !mathutils.AlmostEqual(got.Delta, q, 1e-12) {
+		t.Errorf("Expected Delta: %f, got: %f", q, got.Delta)
+	}
+}
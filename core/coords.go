@@ -0,0 +1,225 @@
+package core
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/constants"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Position of a celestial body on the celestial sphere in the equatorial system.
+type EquatorialPosition struct {
+	// right ascension, degrees
+	Alpha float64
+	// declination, degrees
+	Delta float64
+}
+
+// Position of a celestial body in the horizontal system.
+type HorizontalPosition struct {
+	// azimuth, degrees, measured from the North eastwards
+	Azimuth float64
+	// altitude above the horizon, degrees
+	Altitude float64
+}
+
+// Converts ecliptic position to equatorial, given obliquity of the ecliptic, eps.
+// All angles in arc-degrees. Distance is ignored.
+func EclipticToEquatorial(pos EclipticPosition, eps float64) EquatorialPosition {
+	l := mathutils.Radians(pos.Lambda)
+	b := mathutils.Radians(pos.Beta)
+	e := mathutils.Radians(eps)
+	sinl, cosl := math.Sincos(l)
+	sinb, cosb := math.Sincos(b)
+	sine, cose := math.Sincos(e)
+	a := math.Atan2(sinl*cose-(sinb/cosb)*sine, cosl)
+	d := math.Asin(sinb*cose + cosb*sine*sinl)
+	return EquatorialPosition{
+		Alpha: mathutils.ReduceDeg(mathutils.Degrees(a)),
+		Delta: mathutils.Degrees(d),
+	}
+}
+
+// Converts equatorial position to ecliptic, given obliquity of the ecliptic, eps.
+// All angles in arc-degrees. Distance of the result is set to 0.
+func EquatorialToEcliptic(pos EquatorialPosition, eps float64) EclipticPosition {
+	a := mathutils.Radians(pos.Alpha)
+	d := mathutils.Radians(pos.Delta)
+	e := mathutils.Radians(eps)
+	sina, cosa := math.Sincos(a)
+	sind, cosd := math.Sincos(d)
+	sine, cose := math.Sincos(e)
+	l := math.Atan2(sina*cose+(sind/cosd)*sine, cosa)
+	b := math.Asin(sind*cose - cosd*sine*sina)
+	return EclipticPosition{
+		Lambda: mathutils.ReduceDeg(mathutils.Degrees(l)),
+		Beta:   mathutils.Degrees(b),
+	}
+}
+
+// True obliquity of the ecliptic, arc-degrees, for jd, Standard Julian Date,
+// i.e. Laskar's mean obliquity of [MeanObliquity] corrected for nutation in obliquity.
+func TrueObliquity(jd float64) float64 {
+	return ComputeNutation(jd).TrueObliquity(jd)
+}
+
+// Converts ecliptic position to equatorial using true obliquity of the ecliptic
+// for jd, Standard Julian Date. See [EclipticToEquatorial] for a custom obliquity.
+func EclipticToEquatorialOfDate(pos EclipticPosition, jd float64) EquatorialPosition {
+	return EclipticToEquatorial(pos, TrueObliquity(jd))
+}
+
+// Converts equatorial position to ecliptic using true obliquity of the ecliptic
+// for jd, Standard Julian Date. See [EquatorialToEcliptic] for a custom obliquity.
+func EquatorialToEclipticOfDate(pos EquatorialPosition, jd float64) EclipticPosition {
+	return EquatorialToEcliptic(pos, TrueObliquity(jd))
+}
+
+// Converts equatorial coordinates to horizontal, given ha, the local hour angle,
+// delta, the declination and phi, the geographical latitude of the observer.
+// All angles in arc-degrees.
+func EquatorialToHorizontal(ha, delta, phi float64) HorizontalPosition {
+	h := mathutils.Radians(ha)
+	d := mathutils.Radians(delta)
+	p := mathutils.Radians(phi)
+	sinh, cosh := math.Sincos(h)
+	sind, cosd := math.Sincos(d)
+	sinp, cosp := math.Sincos(p)
+	alt := math.Asin(sinp*sind + cosp*cosd*cosh)
+	az := math.Atan2(-cosd*sinh, sind*cosp-cosd*sinp*cosh)
+	return HorizontalPosition{
+		Azimuth:  mathutils.ReduceDeg(mathutils.Degrees(az)),
+		Altitude: mathutils.Degrees(alt),
+	}
+}
+
+// Atmospheric refraction, arc-degrees, for alt, true (airless) altitude in arc-degrees,
+// under standard conditions (Saemundsson's formula, Meeus, 16.4).
+// Below -1 degree the refraction is not defined and 0 is returned.
+func Refraction(alt float64) float64 {
+	if alt < -1 {
+		return 0
+	}
+	r := 1.02 / math.Tan(mathutils.Radians(alt+10.3/(alt+5.11))) // arc-minutes
+	return r / 60
+}
+
+// Converts rectangular ecliptic coordinates to spherical.
+// Zero vector has no direction, in this case zero position is returned.
+func RectangularToSpherical(x, y, z float64) EclipticPosition {
+	r := math.Sqrt(x*x + y*y + z*z)
+	if r == 0 {
+		return EclipticPosition{}
+	}
+	return EclipticPosition{
+		Lambda: mathutils.ReduceDeg(mathutils.Degrees(math.Atan2(y, x))),
+		Beta:   mathutils.Degrees(math.Asin(z / r)),
+		Delta:  r,
+	}
+}
+
+// Rotates position vector by Euler angles, arc-degrees, in Z-X-Z order:
+// by eulerAngles[0] around Z axis, then by eulerAngles[1] around X axis,
+// then by eulerAngles[2] around Z axis. Positive angles are counter-clockwise when
+// seen from the positive end of the axis. Distance is preserved.
+//
+// Rotation by the obliquity of the ecliptic around X axis, {0, eps, 0},
+// gives equatorial coordinates, see [EclipticToEquatorial].
+func RotateEcliptic(pos EclipticPosition, eulerAngles [3]float64) EclipticPosition {
+	x, y, z := pos.Rectangular()
+	sin0, cos0 := math.Sincos(mathutils.Radians(eulerAngles[0]))
+	sin1, cos1 := math.Sincos(mathutils.Radians(eulerAngles[1]))
+	sin2, cos2 := math.Sincos(mathutils.Radians(eulerAngles[2]))
+	x, y = x*cos0-y*sin0, x*sin0+y*cos0
+	y, z = y*cos1-z*sin1, y*sin1+z*cos1
+	x, y = x*cos2-y*sin2, x*sin2+y*cos2
+	return RectangularToSpherical(x, y, z)
+}
+
+// Converts heliocentric ecliptic position of a body to geocentric,
+// given heliocentric position of the Earth. Both positions must refer
+// to the same equinox and use the same units of distance.
+//
+// Heliocentric longitude of the Earth is the geocentric longitude of the Sun plus 180,
+// and its latitude is the Sun's latitude with reversed sign.
+// When the body coincides with the Earth, direction is undefined and zero position is returned.
+func HeliocentricToGeocentric(body, earth EclipticPosition) EclipticPosition {
+	return body.Sub(earth)
+}
+
+// Angular separation, arc-degrees, between two points of the celestial sphere.
+// Haversine formula is used, so that the result is accurate for small separations too.
+func AngularSeparation(a, b EquatorialPosition) float64 {
+	return haversine(a.Alpha, a.Delta, b.Alpha, b.Delta)
+}
+
+// Phase angle Sun-Moon-Earth, arc-degrees, in range 0..180, i.e. the angle at the Moon
+// between directions to the Sun and to the Earth, given geocentric positions of the Sun
+// and the Moon in the same units of distance. 0 corresponds to Full Moon, 180 to New Moon.
+//
+// The angle is found from rectangular vectors; compare with the formula using
+// elongation of the Moon from the Sun (Meeus, 48.3).
+func PhaseAngleVectors(sun, moon EclipticPosition) float64 {
+	mx, my, mz := moon.Rectangular()
+	sx, sy, sz := sun.Rectangular()
+	// from the Moon to the Earth and to the Sun
+	ax, ay, az := -mx, -my, -mz
+	bx, by, bz := sx-mx, sy-my, sz-mz
+	dot := ax*bx + ay*by + az*bz
+	cx, cy, cz := ay*bz-az*by, az*bx-ax*bz, ax*by-ay*bx
+	return mathutils.Degrees(math.Atan2(math.Sqrt(cx*cx+cy*cy+cz*cz), dot))
+}
+
+// Central angle, arc-degrees, between two points of a sphere given their
+// longitudes and latitudes, arc-degrees.
+func haversine(lon1, lat1, lon2, lat2 float64) float64 {
+	a1, d1 := mathutils.Radians(lon1), mathutils.Radians(lat1)
+	a2, d2 := mathutils.Radians(lon2), mathutils.Radians(lat2)
+	sd := math.Sin((d2 - d1) / 2)
+	sa := math.Sin((a2 - a1) / 2)
+	h := sd*sd + math.Cos(d1)*math.Cos(d2)*sa*sa
+	return mathutils.Degrees(2 * math.Asin(math.Sqrt(math.Min(h, 1))))
+}
+
+// Great-circle distance between two points of the Earth's surface, given their geographical
+// latitudes and longitudes, arc-degrees. The result is the central angle, arc-degrees.
+// Haversine formula is used, which is accurate for small distances too.
+func GreatCircleDistance(lat1, lon1, lat2, lon2 float64) float64 {
+	return haversine(lon1, lat1, lon2, lat2)
+}
+
+// Same as [GreatCircleDistance], in kilometers. The Earth is treated as a sphere
+// of mean radius, so that the error may reach 0.5% comparing to the ellipsoid.
+func GreatCircleDistanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	return mathutils.Radians(GreatCircleDistance(lat1, lon1, lat2, lon2)) * constants.EARTH_MEAN_RADIUS
+}
+
+// Quantities rho*sin(phi') and rho*cos(phi'), where phi' is the geocentric latitude
+// and rho is the distance from the Earth's center in units of the equatorial radius,
+// given lat, geographical latitude, arc-degrees, and elevation, meters above sea level.
+func GeocentricLatitude(lat, elevation float64) (rhoSin, rhoCos float64) {
+	const ba = 1 - constants.EARTH_FLATTENING
+	h := elevation / 1000 / constants.EARTH_RADIUS
+	sinu, cosu := math.Sincos(math.Atan(ba * math.Tan(mathutils.Radians(lat))))
+	sinp, cosp := math.Sincos(mathutils.Radians(lat))
+	return ba*sinu + h*sinp, cosu + h*cosp
+}
+
+// Corrects geocentric equatorial position of a body for parallax,
+// given the body's equatorial horizontal parallax, arc-degrees, ha, the local hour angle,
+// and lat, the geographical latitude of the observer. elevation is the observer's height
+// above sea level, meters. Result is topocentric equatorial position.
+//
+// Meeus, "Astronomical Algorithms", 40.2, 40.3.
+func EquatorialToTopocentric(pos EquatorialPosition, parallax, ha, lat, elevation float64) EquatorialPosition {
+	rs, rc := GeocentricLatitude(lat, elevation)
+	sinp := math.Sin(mathutils.Radians(parallax))
+	sinh, cosh := math.Sincos(mathutils.Radians(ha))
+	sind, cosd := math.Sincos(mathutils.Radians(pos.Delta))
+	da := math.Atan2(-rc*sinp*sinh, cosd-rc*sinp*cosh)
+	d := math.Atan2((sind-rs*sinp)*math.Cos(da), cosd-rc*sinp*cosh)
+	return EquatorialPosition{
+		Alpha: mathutils.ReduceDeg(pos.Alpha + mathutils.Degrees(da)),
+		Delta: mathutils.Degrees(d),
+	}
+}
@@ -0,0 +1,214 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Pollux, Meeus, example 13.a
+var pollux = struct {
+	equ EquatorialPosition
+	ecl EclipticPosition
+	eps float64
+}{
+	equ: EquatorialPosition{Alpha: 116.328942, Delta: 28.026183},
+	ecl: EclipticPosition{Lambda: 113.215630, Beta: 6.684170},
+	eps: 23.4392911,
+}
+
+func TestEclipticToEquatorial(t *testing.T) {
+	got := EclipticToEquatorial(pollux.ecl, pollux.eps)
+	if !mathutils.AlmostEqual(got.Alpha, pollux.equ.Alpha, 1e-5) {
+		t.Errorf("Expected Alpha: %f, got: %f", pollux.equ.Alpha, got.Alpha)
+	}
+	if !mathutils.AlmostEqual(got.Delta, pollux.equ.Delta, 1e-5) {
+		t.Errorf("Expected Delta: %f, got: %f", pollux.equ.Delta, got.Delta)
+	}
+}
+
+func TestEquatorialToEcliptic(t *testing.T) {
+	got := EquatorialToEcliptic(pollux.equ, pollux.eps)
+	if !mathutils.AlmostEqual(got.Lambda, pollux.ecl.Lambda, 1e-5) {
+		t.Errorf("Expected Lambda: %f, got: %f", pollux.ecl.Lambda, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Beta, pollux.ecl.Beta, 1e-5) {
+		t.Errorf("Expected Beta: %f, got: %f", pollux.ecl.Beta, got.Beta)
+	}
+}
+
+func TestEclipticToEquatorialOfDate(t *testing.T) {
+	jd := 2446895.5
+	eps := TrueObliquity(jd)
+	// Meeus, example 22.a, 1987 April 10, 0h TD: 23 26 36.850
+	if !mathutils.AlmostEqual(eps, 23.443569, 1e-3) {
+		t.Errorf("Expected obliquity: %f, got: %f", 23.443569, eps)
+	}
+	got := EclipticToEquatorialOfDate(pollux.ecl, jd)
+	exp := EclipticToEquatorial(pollux.ecl, eps)
+	if got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+	back := EquatorialToEclipticOfDate(got, jd)
+	if !mathutils.AlmostEqual(back.Lambda, pollux.ecl.Lambda, 1e-9) {
+		t.Errorf("Expected Lambda: %f, got: %f", pollux.ecl.Lambda, back.Lambda)
+	}
+}
+
+func TestEquatorialToHorizontal(t *testing.T) {
+	// Duffett-Smith, "Practical Astronomy With Your Calculator", section 25
+	got := EquatorialToHorizontal(87.933333, 23.219444, 52)
+	if !mathutils.AlmostEqual(got.Altitude, 19.334444, 1e-4) {
+		t.Errorf("Expected Altitude: %f, got: %f", 19.334444, got.Altitude)
+	}
+	if !mathutils.AlmostEqual(got.Azimuth, 283.271111, 1e-4) {
+		t.Errorf("Expected Azimuth: %f, got: %f", 283.271111, got.Azimuth)
+	}
+}
+
+func TestRefraction(t *testing.T) {
+	// Meeus, chapter 16: about 29 arc-minutes at the horizon
+	got := Refraction(0) * 60
+	if !mathutils.AlmostEqual(got, 29, 0.5) {
+		t.Errorf("Expected: %f, got: %f", 29.0, got)
+	}
+	if got := Refraction(90); !mathutils.AlmostEqual(got, 0, 1e-4) {
+		t.Errorf("Expected: 0, got: %f", got)
+	}
+}
+
+func TestRectangularRoundTrip(t *testing.T) {
+	pos := EclipticPosition{Lambda: 113.21563, Beta: -6.68417, Delta: 2.5}
+	got := RectangularToSpherical(pos.Rectangular())
+	if !mathutils.AlmostEqual(got.Lambda, pos.Lambda, 1e-9) {
+		t.Errorf("Expected Lambda: %f, got: %f", pos.Lambda, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Beta, pos.Beta, 1e-9) {
+		t.Errorf("Expected Beta: %f, got: %f", pos.Beta, got.Beta)
+	}
+	if !mathutils.AlmostEqual(got.Delta, pos.Delta, 1e-9) {
+		t.Errorf("Expected Delta: %f, got: %f", pos.Delta, got.Delta)
+	}
+}
+
+func TestRotateEcliptic(t *testing.T) {
+	pos := pollux.ecl
+	pos.Delta = 1
+	got := RotateEcliptic(pos, [3]float64{0, pollux.eps, 0})
+	if !mathutils.AlmostEqual(got.Lambda, pollux.equ.Alpha, 1e-5) {
+		t.Errorf("Expected Alpha: %f, got: %f", pollux.equ.Alpha, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Beta, pollux.equ.Delta, 1e-5) {
+		t.Errorf("Expected Delta: %f, got: %f", pollux.equ.Delta, got.Beta)
+	}
+	// rotations around Z axis shift longitude
+	got = RotateEcliptic(pos, [3]float64{10, 0, 20})
+	if !mathutils.AlmostEqual(got.Lambda, pos.Lambda+30, 1e-9) {
+		t.Errorf("Expected Lambda: %f, got: %f", pos.Lambda+30, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Beta, pos.Beta, 1e-9) {
+		t.Errorf("Expected Beta: %f, got: %f", pos.Beta, got.Beta)
+	}
+	// inverse rotation restores the position
+	back := RotateEcliptic(RotateEcliptic(pos, [3]float64{30, 40, 50}), [3]float64{-50, -40, -30})
+	if !mathutils.AlmostEqual(back.Lambda, pos.Lambda, 1e-9) || !mathutils.AlmostEqual(back.Beta, pos.Beta, 1e-9) {
+		t.Errorf("Expected: %v, got: %v", pos, back)
+	}
+}
+
+func TestHeliocentricToGeocentric(t *testing.T) {
+	body := EclipticPosition{Lambda: 0, Beta: 0, Delta: 1.5}
+	earth := EclipticPosition{Lambda: 90, Beta: 0, Delta: 1}
+	got := HeliocentricToGeocentric(body, earth)
+	if !mathutils.AlmostEqual(got.Lambda, 326.309932, 1e-6) {
+		t.Errorf("Expected Lambda: %f, got: %f", 326.309932, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Beta, 0, 1e-9) {
+		t.Errorf("Expected Beta: 0, got: %f", got.Beta)
+	}
+	if !mathutils.AlmostEqual(got.Delta, 1.802776, 1e-6) {
+		t.Errorf("Expected Delta: %f, got: %f", 1.802776, got.Delta)
+	}
+}
+
+func TestHeliocentricToGeocentricCoincident(t *testing.T) {
+	earth := EclipticPosition{Lambda: 90, Beta: 0, Delta: 1}
+	got := HeliocentricToGeocentric(earth, earth)
+	if got != (EclipticPosition{}) {
+		t.Errorf("Expected zero position, got: %v", got)
+	}
+}
+
+func TestAngularSeparation(t *testing.T) {
+	// Meeus, example 17.a: Arcturus and Spica
+	arcturus := EquatorialPosition{Alpha: 213.9154, Delta: 19.1825}
+	spica := EquatorialPosition{Alpha: 201.2983, Delta: -11.1614}
+	got := AngularSeparation(arcturus, spica)
+	if !mathutils.AlmostEqual(got, 32.7930, 1e-4) {
+		t.Errorf("Expected: %f, got: %f", 32.7930, got)
+	}
+	if got := AngularSeparation(spica, spica); got != 0 {
+		t.Errorf("Expected: 0, got: %f", got)
+	}
+}
+
+func TestPhaseAngleVectors(t *testing.T) {
+	// Meeus, example 48.a: elongation 110.7929, distances of the Sun and the Moon, km
+	sun := EclipticPosition{Lambda: 20, Beta: 0, Delta: 149971520}
+	moon := EclipticPosition{Lambda: 130.7929, Beta: 0, Delta: 368410}
+	if got := PhaseAngleVectors(sun, moon); !mathutils.AlmostEqual(got, 69.0756, 1e-4) {
+		t.Errorf("Expected: %f, got: %f", 69.0756, got)
+	}
+	// compare with the formula using elongation psi, Meeus, 48.3
+	sun = EclipticPosition{Lambda: 45, Beta: 0, Delta: 1.0123}
+	for _, moon := range []EclipticPosition{
+		{Lambda: 45, Beta: 5, Delta: 0.0025},
+		{Lambda: 130, Beta: -3, Delta: 0.0027},
+		{Lambda: 230, Beta: 1, Delta: 0.0024},
+	} {
+		psi := math.Acos(math.Cos(mathutils.Radians(moon.Beta)) * math.Cos(mathutils.Radians(moon.Lambda-sun.Lambda)))
+		exp := mathutils.Degrees(math.Atan2(sun.Delta*math.Sin(psi), moon.Delta-sun.Delta*math.Cos(psi)))
+		if got := PhaseAngleVectors(sun, moon); !mathutils.AlmostEqual(got, exp, 1e-9) {
+			t.Errorf("Expected: %f, got: %f", exp, got)
+		}
+	}
+}
+
+func TestGreatCircleDistance(t *testing.T) {
+	// Meeus, example 11.c: Paris and US Naval Observatory at Washington
+	lat1, lon1 := 48.836389, 2.337222
+	lat2, lon2 := 38.921389, -77.065556
+	if got := GreatCircleDistance(lat1, lon1, lat2, lon2); !mathutils.AlmostEqual(got, 55.448548, 1e-5) {
+		t.Errorf("Expected: %f, got: %f", 55.448548, got)
+	}
+	if got := GreatCircleDistanceKm(lat1, lon1, lat2, lon2); !mathutils.AlmostEqual(got, 6166, 1) {
+		t.Errorf("Expected: %f, got: %f", 6166.0, got)
+	}
+	if got := GreatCircleDistance(lat1, lon1, lat1, lon1); got != 0 {
+		t.Errorf("Expected: 0, got: %f", got)
+	}
+}
+
+func TestGeocentricLatitude(t *testing.T) {
+	// Meeus, example 11.a: Palomar Observatory
+	rs, rc := GeocentricLatitude(33.356111, 1706)
+	if !mathutils.AlmostEqual(rs, 0.546861, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 0.546861, rs)
+	}
+	if !mathutils.AlmostEqual(rc, 0.836339, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 0.836339, rc)
+	}
+}
+
+func TestEquatorialToTopocentric(t *testing.T) {
+	// Meeus, example 40.a: Mars from Palomar Observatory
+	pos := EquatorialPosition{Alpha: 339.530208, Delta: -15.771083}
+	got := EquatorialToTopocentric(pos, 23.592/3600, 288.7958, 33.356111, 1706)
+	if !mathutils.AlmostEqual(got.Alpha, 339.535583, 5e-5) {
+		t.Errorf("Expected Alpha: %f, got: %f", 339.535583, got.Alpha)
+	}
+	if !mathutils.AlmostEqual(got.Delta, -15.775011, 5e-5) {
+		t.Errorf("Expected Delta: %f, got: %f", -15.775011, got.Delta)
+	}
+}
@@ -0,0 +1,163 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Keplerian elements of an elliptic orbit.
+// All angular values are in arc-degrees.
+type OrbitalElements struct {
+	// semi-major axis, A.U.
+	A float64
+	// eccentricity
+	E float64
+	// inclination
+	I float64
+	// longitude of the ascending node
+	Node float64
+	// argument of perihelion
+	ArgPeri float64
+	// mean anomaly at epoch
+	M float64
+	// epoch of osculation, Standard Julian Date
+	Epoch float64
+	// secular rate of the longitude of perihelion, arc-degrees per Julian century,
+	// optional; see [PerihelionLongitude]
+	PeriRate float64
+}
+
+// Gaussian gravitational constant, radians per day
+const GAUSS_K = 0.01720209895
+
+// Units of angular values in JSON representation of orbital elements.
+const (
+	UNITS_DEGREES = "deg"
+	UNITS_RADIANS = "rad"
+)
+
+// JSON number which may be also given as a string, as JPL and MPC services do.
+// null, which JPL exports for missing values, leaves the value unchanged.
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	s := strings.Trim(string(data), `"`)
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("invalid number %s: %w", data, err)
+	}
+	*f = flexFloat(v)
+	return nil
+}
+
+// JSON layout using field names common for MPC and JPL element sets.
+// Required fields are pointers, which remain nil when the fields are missing or null.
+type elementsJSON struct {
+	A     *flexFloat `json:"a"`
+	E     *flexFloat `json:"e"`
+	I     flexFloat  `json:"i"`
+	Om    flexFloat  `json:"om"`
+	W     flexFloat  `json:"w"`
+	Ma    flexFloat  `json:"ma"`
+	Epoch *flexFloat `json:"epoch"`
+	Rate  flexFloat  `json:"peri_rate,omitempty"`
+	Units string     `json:"units,omitempty"`
+}
+
+// Serializes elements to JSON. Angles are written in degrees, which is
+// stated explicitly by "units" field.
+func (el OrbitalElements) MarshalJSON() ([]byte, error) {
+	a, e, epoch := flexFloat(el.A), flexFloat(el.E), flexFloat(el.Epoch)
+	return json.Marshal(elementsJSON{
+		A:     &a,
+		E:     &e,
+		I:     flexFloat(el.I),
+		Om:    flexFloat(el.Node),
+		W:     flexFloat(el.ArgPeri),
+		Ma:    flexFloat(el.M),
+		Epoch: &epoch,
+		Rate:  flexFloat(el.PeriRate),
+		Units: UNITS_DEGREES,
+	})
+}
+
+// Parses JSON object with "a", "e", "i", "om", "w", "ma", "epoch" and optional "peri_rate" fields.
+// Values may be numbers or numeric strings. Optional "units" field tells
+// whether the angles are in degrees ("deg", the default) or radians ("rad").
+// Radians are converted to degrees. null values are treated as missing ones.
+//
+// An error is returned when "a", "e" or "epoch" is missing, or when the elements
+// do not describe an elliptic orbit, i.e. "a" is not positive or "e" is not in range 0..1.
+func (el *OrbitalElements) UnmarshalJSON(data []byte) error {
+	var raw elementsJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	angle := func(x flexFloat) float64 { return float64(x) }
+	switch raw.Units {
+	case "", UNITS_DEGREES:
+	case UNITS_RADIANS:
+		angle = func(x flexFloat) float64 { return mathutils.Degrees(float64(x)) }
+	default:
+		return fmt.Errorf("unknown angular units: %q", raw.Units)
+	}
+	switch {
+	case raw.A == nil:
+		return errors.New("missing semi-major axis")
+	case raw.E == nil:
+		return errors.New("missing eccentricity")
+	case raw.Epoch == nil:
+		return errors.New("missing epoch")
+	case *raw.A <= 0:
+		return fmt.Errorf("semi-major axis must be positive, got: %v", *raw.A)
+	case *raw.E < 0 || *raw.E >= 1:
+		return fmt.Errorf("eccentricity of elliptic orbit must be in range 0..1, got: %v", *raw.E)
+	}
+	*el = OrbitalElements{
+		A:        float64(*raw.A),
+		E:        float64(*raw.E),
+		I:        angle(raw.I),
+		Node:     angle(raw.Om),
+		ArgPeri:  angle(raw.W),
+		M:        angle(raw.Ma),
+		Epoch:    float64(*raw.Epoch),
+		PeriRate: angle(raw.Rate),
+	}
+	return nil
+}
+
+// Mean daily motion, arc-degrees per day, of a body with negligible mass
+// moving around the Sun.
+func (el OrbitalElements) MeanMotion() float64 {
+	return mathutils.Degrees(GAUSS_K / math.Pow(el.A, 1.5))
+}
+
+// Standard Julian Date of the perihelion passage, when mean anomaly is 0.
+// Of all the passages, the one nearest to the epoch of the elements is returned,
+// so that the result is within half a period from el.Epoch.
+func PerihelionTime(el OrbitalElements) float64 {
+	m := mathutils.ReduceDeg(el.M)
+	if m > 180 {
+		m -= 360
+	}
+	return el.Epoch - m/el.MeanMotion()
+}
+
+// Longitude of perihelion, Node + ArgPeri, arc-degrees, in range 0..360, at jd,
+// Standard Julian Date. It is shifted from the epoch of the elements by el.PeriRate
+// per Julian century, which accounts for the secular precession of the orbit,
+// e.g. 1.72 degrees per century for the Earth relative to the equinox of date.
+func PerihelionLongitude(el OrbitalElements, jd float64) float64 {
+	t := (jd - el.Epoch) / julian.DAYS_PER_CENT
+	return mathutils.ReduceDeg(el.Node + el.ArgPeri + el.PeriRate*t)
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Ceres, rounded osculating elements in JPL Small-Body Database style,
+// where values are given as strings.
+const _CERES_JSON = `{
+	"a": "2.7675",
+	"e": "0.0785",
+	"i": "10.589",
+	"om": "80.267",
+	"w": "73.597",
+	"ma": "60.079",
+	"epoch": "2459600.5"
+}`
+
+var ceres = OrbitalElements{
+	A:       2.7675,
+	E:       0.0785,
+	I:       10.589,
+	Node:    80.267,
+	ArgPeri: 73.597,
+	M:       60.079,
+	Epoch:   2459600.5,
+}
+
+func assertElements(t *testing.T, exp, got OrbitalElements) {
+	pairs := [...][2]float64{
+		{exp.A, got.A},
+		{exp.E, got.E},
+		{exp.I, got.I},
+		{exp.Node, got.Node},
+		{exp.ArgPeri, got.ArgPeri},
+		{exp.M, got.M},
+		{exp.Epoch, got.Epoch},
+		{exp.PeriRate, got.PeriRate},
+	}
+	for _, p := range pairs {
+		if !mathutils.AlmostEqual(p[0], p[1], 1e-9) {
+			t.Errorf("Expected: %f, got: %f", p[0], p[1])
+		}
+	}
+}
+
+func TestUnmarshalElements(t *testing.T) {
+	var got OrbitalElements
+	if err := json.Unmarshal([]byte(_CERES_JSON), &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertElements(t, ceres, got)
+}
+
+func TestUnmarshalElementsRadians(t *testing.T) {
+	data := `{"a": 2.7675, "e": 0.0785, "i": 0.18481, "om": 1.40092, "w": 1.28451,
+		"ma": 1.04858, "epoch": 2459600.5, "units": "rad"}`
+	var got OrbitalElements
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !mathutils.AlmostEqual(got.I, ceres.I, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", ceres.I, got.I)
+	}
+	if !mathutils.AlmostEqual(got.Node, ceres.Node, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", ceres.Node, got.Node)
+	}
+}
+
+func TestUnmarshalElementsBadUnits(t *testing.T) {
+	var got OrbitalElements
+	if err := json.Unmarshal([]byte(`{"a": 1, "units": "grad"}`), &got); err == nil {
+		t.Error("Expected error for unknown units")
+	}
+}
+
+func TestUnmarshalElementsNull(t *testing.T) {
+	// JPL exports missing values as null
+	data := `{"a": "2.7675", "e": "0.0785", "i": "10.589", "om": "80.267", "w": "73.597",
+		"ma": "60.079", "epoch": "2459600.5", "peri_rate": null}`
+	var got OrbitalElements
+	if err := json.Unmarshal([]byte(data), &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertElements(t, ceres, got)
+}
+
+func TestUnmarshalElementsInvalid(t *testing.T) {
+	for _, data := range []string{
+		`{"e": 0.0785, "epoch": 2459600.5}`,
+		`{"a": null, "e": 0.0785, "epoch": 2459600.5}`,
+		`{"a": 2.7675, "epoch": 2459600.5}`,
+		`{"a": 2.7675, "e": 0.0785}`,
+		`{"a": 0, "e": 0.0785, "epoch": 2459600.5}`,
+		`{"a": 2.7675, "e": 1, "epoch": 2459600.5}`,
+		`{"a": 2.7675, "e": -0.1, "epoch": 2459600.5}`,
+	} {
+		var got OrbitalElements
+		if err := json.Unmarshal([]byte(data), &got); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestMarshalElements(t *testing.T) {
+	data, err := json.Marshal(ceres)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var got OrbitalElements
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertElements(t, ceres, got)
+}
+
+func TestMeanMotion(t *testing.T) {
+	// about 0.9856 degrees per day for the Earth
+	el := OrbitalElements{A: 1}
+	if got := el.MeanMotion(); !mathutils.AlmostEqual(got, 0.985608, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 0.985608, got)
+	}
+}
+
+func TestPerihelionTime(t *testing.T) {
+	cases := [...]struct {
+		m   float64
+		exp float64
+	}{
+		{m: 0, exp: 2451545.0},
+		// a quarter of the year after perihelion
+		{m: 90, exp: 2451545.0 - 91.314225},
+		// a quarter of the year before the next perihelion
+		{m: 270, exp: 2451545.0 + 91.314225},
+		{m: -90, exp: 2451545.0 + 91.314225},
+	}
+	for _, test := range cases {
+		el := OrbitalElements{A: 1, E: 0.0167, M: test.m, Epoch: 2451545.0}
+		if got := PerihelionTime(el); !mathutils.AlmostEqual(got, test.exp, 1e-5) {
+			t.Errorf("Expected: %f, got: %f", test.exp, got)
+		}
+	}
+	// Ceres: mean anomaly at perihelion time is 0
+	got := PerihelionTime(ceres)
+	m := ceres.M + (got-ceres.Epoch)*ceres.MeanMotion()
+	if !mathutils.AlmostEqual(m, 0, 1e-9) {
+		t.Errorf("Expected: 0, got: %f", m)
+	}
+	if math.Abs(got-ceres.Epoch) > 180/ceres.MeanMotion() {
+		t.Errorf("Expected the passage nearest to epoch, got: %f", got)
+	}
+}
+
+func TestPerihelionLongitude(t *testing.T) {
+	// without precession the longitude is constant
+	for _, jd := range []float64{ceres.Epoch, ceres.Epoch + 36525, ceres.Epoch - 1e5} {
+		if got := PerihelionLongitude(ceres, jd); !mathutils.AlmostEqual(got, 153.864, 1e-9) {
+			t.Errorf("Expected: %f, got: %f", 153.864, got)
+		}
+	}
+	// the Earth, Meeus, table 31.A: 102.937348 + 1.7195269 * T
+	earth := OrbitalElements{A: 1, ArgPeri: 102.937348, Epoch: 2451545.0, PeriRate: 1.7195269}
+	if got := PerihelionLongitude(earth, 2451545.0+36525); !mathutils.AlmostEqual(got, 104.656875, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 104.656875, got)
+	}
+	// the rate survives JSON round trip
+	data, _ := json.Marshal(earth)
+	var got OrbitalElements
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	assertElements(t, earth, got)
+}
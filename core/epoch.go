@@ -0,0 +1,42 @@
+package core
+
+import "github.com/skrushinsky/scaliger/julian"
+
+// Standard Julian Date of B1900.0
+const _B1900 = 2415020.31352
+
+// Length of the tropical year, days
+const _TROPICAL_YEAR = 365.242198781
+
+// Length of the Julian year, days
+const _JULIAN_YEAR = 365.25
+
+// Converts Besselian epoch, e.g. 1950.0 for B1950.0, to Standard Julian Date
+// (Lieske, 1979).
+func BesselianToJulian(year float64) float64 {
+	return _B1900 + (year-1900)*_TROPICAL_YEAR
+}
+
+// Converts Standard Julian Date to Besselian epoch.
+func JulianToBesselian(jd float64) float64 {
+	return 1900 + (jd-_B1900)/_TROPICAL_YEAR
+}
+
+// Converts Julian epoch, e.g. 2000.0 for J2000.0, to Standard Julian Date.
+func JulianEpochToJulian(year float64) float64 {
+	return julian.J2000 + (year-2000)*_JULIAN_YEAR
+}
+
+// Converts Standard Julian Date to Julian epoch.
+func JulianToJulianEpoch(jd float64) float64 {
+	return 2000 + (jd-julian.J2000)/_JULIAN_YEAR
+}
+
+// Standard Julian Date of 00:00 local mean time of a civil date at geographical
+// longitude lng, arc-degrees, negative westwards. Local midnight comes earlier
+// than Greenwich midnight east of Greenwich and later to the west of it,
+// by 4 minutes per degree of longitude.
+func LocalMidnightJD(year, month, day int, lng float64) float64 {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: year, Month: month, Day: float64(day)})
+	return jd - lng/360
+}
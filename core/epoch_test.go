@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestBesselianToJulian(t *testing.T) {
+	// B1950.0 = JD 2433282.4235
+	if got := BesselianToJulian(1950); !mathutils.AlmostEqual(got, 2433282.4235, 1e-4) {
+		t.Errorf("Expected: %f, got: %f", 2433282.4235, got)
+	}
+	if got := JulianToBesselian(2433282.4235); !mathutils.AlmostEqual(got, 1950, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 1950.0, got)
+	}
+}
+
+func TestJulianEpochToJulian(t *testing.T) {
+	// J2050.0 = JD 2469807.5
+	if got := JulianEpochToJulian(2050); !mathutils.AlmostEqual(got, 2469807.5, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", 2469807.5, got)
+	}
+	if got := JulianToJulianEpoch(2469807.5); !mathutils.AlmostEqual(got, 2050, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", 2050.0, got)
+	}
+}
+
+func TestLocalMidnightJD(t *testing.T) {
+	// 2024 June 21, 0h UT = JD 2460482.5; at 75W local midnight is 5 hours later
+	if got := LocalMidnightJD(2024, 6, 21, -75); !mathutils.AlmostEqual(got, 2460482.708333, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 2460482.708333, got)
+	}
+	// at 90E it is 6 hours earlier, on the previous UT date
+	if got := LocalMidnightJD(2024, 6, 21, 90); !mathutils.AlmostEqual(got, 2460482.25, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 2460482.25, got)
+	}
+}
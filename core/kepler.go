@@ -1,9 +1,16 @@
 package core
 
-import "math"
+import (
+	"math"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
 
 const _DLA_DELTA = 1e-7 // precision for Kepler equation
 
+// Maximal number of steps of [EccentricAnomalySteps]
+const _KEPLER_MAX_ITER = 100
+
 // Solve Kepler equation to calculate ea, the eccentric anomaly,
 // in elliptical motion given s (< 1), the eccentricity, and m, mean anomaly.
 // All agular values are in radians.
@@ -17,8 +24,48 @@ func EccentricAnomaly(s, m, ea float64) float64 {
 	return EccentricAnomaly(s, m, ea-dla)
 }
 
+// Successive approximations of the eccentric anomaly, which [EccentricAnomaly] goes
+// through when started from m, the mean anomaly, e.g. for showing how Newton's method
+// converges. The first element is m, the last one is the solution. Eccentricity s
+// must be less than 1; the closer it is to 1, the more steps are needed.
+// If the method does not converge, e.g. for NaN arguments, the search stops
+// after 100 steps and the approximations collected so far are returned.
+// All angular values are in radians.
+func EccentricAnomalySteps(s, m float64) []float64 {
+	ea := m
+	res := []float64{ea}
+	for i := 0; i < _KEPLER_MAX_ITER; i++ {
+		dla := ea - (s * math.Sin(ea)) - m
+		if math.Abs(dla) < _DLA_DELTA {
+			break
+		}
+		ea -= dla / (1 - (s * math.Cos(ea)))
+		res = append(res, ea)
+	}
+	return res
+}
+
 // Given s, eccentricity, and ea, eccentric anomaly, find true anomaly.
 // All angular values are in radians.
 func TrueAnomaly(s, ea float64) float64 {
 	return 2 * math.Atan(math.Sqrt((1+s)/(1-s))*math.Tan(ea/2))
 }
+
+// Same as [TrueAnomaly], but the result is continuous with ea, the eccentric anomaly,
+// e.g. when ea exceeds 2*pi after a few revolutions. [TrueAnomaly] returns values
+// in range -pi..pi, with a jump at ea = pi; here a multiple of 2*pi is added so that
+// the true anomaly is in the same revolution and the same half of the orbit as ea.
+// All angular values are in radians.
+func TrueAnomalyQuadrant(s, ea float64) float64 {
+	ta := TrueAnomaly(s, ea)
+	return ta + 2*math.Pi*math.Round((ea-ta)/(2*math.Pi))
+}
+
+// Angular diameter, arc-seconds, of a spherical body of a given physical radius, km,
+// at a given distance, km.
+//
+// Small-angle approximation is used: diameter = 2 * radius / distance radians,
+// which is accurate for distances much larger than the radius.
+func AngularDiameter(radius, distance float64) float64 {
+	return mathutils.Degrees(2*radius/distance) * 3600
+}
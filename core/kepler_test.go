@@ -1,6 +1,7 @@
 package core
 
 import (
+	"math"
 	"testing"
 
 	"github.com/skrushinsky/scaliger/mathutils"
@@ -39,6 +40,32 @@ func TestEccentricAnomaly(t *testing.T) {
 	}
 }
 
+func TestEccentricAnomalySteps(t *testing.T) {
+	for _, test := range cases {
+		got := EccentricAnomalySteps(test.s, test.m)
+		if got[0] != test.m {
+			t.Errorf("Expected first step: %f, got: %f", test.m, got[0])
+		}
+		if exp := EccentricAnomaly(test.s, test.m, test.m); got[len(got)-1] != exp {
+			t.Errorf("Expected: %f, got: %f", exp, got[len(got)-1])
+		}
+	}
+	// high eccentricity requires more steps
+	low := EccentricAnomalySteps(cases[0].s, cases[0].m)
+	high := EccentricAnomalySteps(cases[1].s, cases[1].m)
+	if len(high) <= len(low) {
+		t.Errorf("Expected more steps for eccentricity %f: %d, %d", cases[1].s, len(high), len(low))
+	}
+}
+
+func TestEccentricAnomalyStepsNoConvergence(t *testing.T) {
+	for _, s := range []float64{math.NaN(), math.Inf(1)} {
+		if got := EccentricAnomalySteps(s, 1); len(got) != _KEPLER_MAX_ITER+1 {
+			t.Errorf("Expected %d steps for eccentricity %f, got: %d", _KEPLER_MAX_ITER+1, s, len(got))
+		}
+	}
+}
+
 func TestTrueAnomaly(t *testing.T) {
 	for _, test := range cases {
 		ta := TrueAnomaly(test.s, test.ea)
@@ -47,3 +74,30 @@ func TestTrueAnomaly(t *testing.T) {
 		}
 	}
 }
+
+func TestTrueAnomalyQuadrant(t *testing.T) {
+	const s = 0.6
+	prev := TrueAnomalyQuadrant(s, 0)
+	for ea := 0.01; ea <= 3*math.Pi; ea += 0.01 {
+		ta := TrueAnomalyQuadrant(s, ea)
+		// true anomaly grows continuously, fastest at perihelion: dv/dE = sqrt(1 - s^2) / (1 - s*cos(E))
+		if d := ta - prev; d <= 0 || d > 0.03 {
+			t.Fatalf("Discontinuity at ea = %f: %f -> %f", ea, prev, ta)
+		}
+		if exp := TrueAnomaly(s, ea); !mathutils.AlmostEqual(math.Cos(ta), math.Cos(exp), 1e-12) {
+			t.Errorf("Expected the same direction as %f, got: %f", exp, ta)
+		}
+		prev = ta
+	}
+	if got := TrueAnomalyQuadrant(s, 3*math.Pi); !mathutils.AlmostEqual(got, 3*math.Pi, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", 3*math.Pi, got)
+	}
+}
+
+func TestAngularDiameter(t *testing.T) {
+	// the Moon at its mean distance
+	got := AngularDiameter(1737.4, 384400)
+	if !mathutils.AlmostEqual(got, 1864.6, 0.1) {
+		t.Errorf("Expected: %f, got: %f", 1864.6, got)
+	}
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"github.com/skrushinsky/scaliger/nutequ"
+	"github.com/skrushinsky/scaliger/sidereal"
+)
+
+// Nutation in longitude and in obliquity, arc-degrees.
+//
+// Computing nutation is the most expensive part of apparent positions. When several
+// quantities are needed for the same moment, e.g. positions of the Sun and the Moon,
+// obliquity and sidereal time, compute it once with [ComputeNutation] and pass it to
+// functions with WithNutation suffix.
+type Nutation struct {
+	// nutation in longitude
+	Dpsi float64
+	// nutation in obliquity
+	Deps float64
+}
+
+// Nutation for jd, Standard Julian Date.
+func ComputeNutation(jd float64) Nutation {
+	dpsi, deps := nutequ.Nutation(jd)
+	return Nutation{Dpsi: dpsi, Deps: deps}
+}
+
+// True obliquity of the ecliptic, arc-degrees, for jd, Standard Julian Date,
+// given nutation for the same date: [MeanObliquity] plus Deps. See [TrueObliquity].
+func (n Nutation) TrueObliquity(jd float64) float64 {
+	eps, _ := MeanObliquity(jd)
+	return eps + n.Deps
+}
+
+// Local apparent sidereal time, hours, for jd, Standard Julian Date, given nutation
+// for the same date and geographical longitude, lng, arc-degrees, negative westwards.
+// Zero longitude gives Greenwich apparent sidereal time.
+func (n Nutation) SiderealTime(jd, lng float64) float64 {
+	return sidereal.JulianToSidereal(jd, sidereal.SiderealOptions{Lng: lng, Eps: n.TrueObliquity(jd), Dpsi: n.Dpsi})
+}
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+	"github.com/skrushinsky/scaliger/nutequ"
+	"github.com/skrushinsky/scaliger/sidereal"
+)
+
+func TestComputeNutation(t *testing.T) {
+	// Meeus, example 22.a: 1987 April 10, 0h TD, dpsi = -3.788", deps = 9.443"
+	jd := 2446895.5
+	nut := ComputeNutation(jd)
+	if !mathutils.AlmostEqual(nut.Dpsi*3600, -3.788, 0.5) {
+		t.Errorf("Expected Dpsi: %f, got: %f", -3.788, nut.Dpsi*3600)
+	}
+	if !mathutils.AlmostEqual(nut.Deps*3600, 9.443, 0.5) {
+		t.Errorf("Expected Deps: %f, got: %f", 9.443, nut.Deps*3600)
+	}
+	if got, exp := nut.TrueObliquity(jd), TrueObliquity(jd); got != exp {
+		t.Errorf("Expected obliquity: %f, got: %f", exp, got)
+	}
+	dpsi, _ := nutequ.Nutation(jd)
+	exp := sidereal.JulianToSidereal(jd, sidereal.SiderealOptions{Lng: -77, Eps: TrueObliquity(jd), Dpsi: dpsi})
+	if got := nut.SiderealTime(jd, -77); got != exp {
+		t.Errorf("Expected sidereal time: %f, got: %f", exp, got)
+	}
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Laskar's polynomial is valid within 10000 years of J2000.0, i.e. for |U| < 1,
+// where U is time measured in units of 10000 Julian years.
+const _LASKAR_LIMIT = 1.0
+
+// Returned when a date is beyond the validity window of the polynomial.
+var ErrOutOfRange = errors.New("date is out of the validity range")
+
+// Coefficients of Laskar's formula, arc-seconds, in ascending powers of U.
+var laskarTerms = [...]float64{
+	84381.448, -4680.93, -1.55, 1999.25, -51.38, -249.67, -39.05, 7.12, 27.87, 5.79, 2.45,
+}
+
+// Argument U of Laskar's polynomial for jd, Standard Julian Date, clamped to
+// the validity window; [ErrOutOfRange] is returned if it was clamped.
+func laskarU(jd float64) (float64, error) {
+	u := (jd - julian.J2000) / julian.DAYS_PER_CENT / 100
+	if u > _LASKAR_LIMIT {
+		return _LASKAR_LIMIT, ErrOutOfRange
+	} else if u < -_LASKAR_LIMIT {
+		return -_LASKAR_LIMIT, ErrOutOfRange
+	}
+	return u, nil
+}
+
+// Mean obliquity of the ecliptic, arc-degrees, for jd, Standard Julian Date.
+// [TrueObliquity] of the package is based on it.
+//
+// Uses J.Laskar's polynomial (Meeus, "Astronomical Algorithms", 22.3),
+// which is accurate to 0.01" after 1000 years and to a few arc-seconds
+// after 10000 years from J2000.0. Beyond ±10000 years the polynomial diverges,
+// so [ErrOutOfRange] is returned along with the value clamped to the boundary.
+func MeanObliquity(jd float64) (float64, error) {
+	u, err := laskarU(jd)
+	return mathutils.Polynome(u, laskarTerms[:]...) / 3600, err
+}
+
+// Rate of change of the mean obliquity of the ecliptic, arc-seconds per Julian century,
+// for jd, Standard Julian Date. Negative value means that the obliquity is decreasing.
+//
+// The result is a derivative of Laskar's polynomial. Like [MeanObliquity], beyond
+// ±10000 years from J2000.0 it returns [ErrOutOfRange] along with the rate
+// at the boundary of the window.
+func ObliquityRate(jd float64) (float64, error) {
+	u, err := laskarU(jd)
+	terms := make([]float64, 0, len(laskarTerms)-1)
+	for i, k := range laskarTerms[1:] {
+		terms = append(terms, float64(i+1)*k)
+	}
+	// dU/dT = 1/100
+	return mathutils.Polynome(u, terms...) / 100, err
+}
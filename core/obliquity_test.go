@@ -0,0 +1,57 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestMeanObliquity(t *testing.T) {
+	// Meeus, example 22.a, 1987 April 10, 0h TD
+	got, err := MeanObliquity(2446895.5)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	exp := 23.44094629
+	if !mathutils.AlmostEqual(got, exp, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+}
+
+func TestMeanObliquityOutOfRange(t *testing.T) {
+	jd := julian.J2000 + 20000*365.25
+	got, err := MeanObliquity(jd)
+	if err != ErrOutOfRange {
+		t.Errorf("Expected ErrOutOfRange, got: %v", err)
+	}
+	exp, _ := MeanObliquity(julian.J2000 + 10000*365.25)
+	if !mathutils.AlmostEqual(got, exp, 1e-9) {
+		t.Errorf("Expected clamped value: %f, got: %f", exp, got)
+	}
+}
+
+func TestObliquityRate(t *testing.T) {
+	got, err := ObliquityRate(julian.J2000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := -46.8093
+	if !mathutils.AlmostEqual(got, exp, _DELTA) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+	if rate, _ := ObliquityRate(2460310.5); rate >= 0 {
+		t.Errorf("Expected obliquity to decrease in 2024, got rate: %f", rate)
+	}
+}
+
+func TestObliquityRateOutOfRange(t *testing.T) {
+	got, err := ObliquityRate(julian.J2000 - 20000*365.25)
+	if err != ErrOutOfRange {
+		t.Errorf("Expected ErrOutOfRange, got: %v", err)
+	}
+	exp, _ := ObliquityRate(julian.J2000 - 10000*365.25)
+	if !mathutils.AlmostEqual(got, exp, 1e-9) {
+		t.Errorf("Expected clamped value: %f, got: %f", exp, got)
+	}
+}
@@ -0,0 +1,13 @@
+package core
+
+// Geographical position of an observer. Functions accepting an observer, e.g.
+// sun.AltAzForObserver, are equivalent to their counterparts taking
+// longitude and latitude as separate arguments.
+type Observer struct {
+	// geographical longitude, arc-degrees, negative westwards
+	Longitude float64
+	// geographical latitude, arc-degrees, negative southwards
+	Latitude float64
+	// height above sea level, meters
+	Elevation float64
+}
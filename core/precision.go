@@ -0,0 +1,14 @@
+package core
+
+// Level of accuracy of the Sun and Moon positions. Lower levels use truncated
+// theories and skip corrections, which makes them faster.
+type Precision int
+
+const (
+	// truncated theory, no nutation
+	PrecisionLow Precision = iota
+	// full theory, no nutation, positions refer to the mean equinox of date
+	PrecisionMedium
+	// full theory corrected for nutation, positions refer to the true equinox of date
+	PrecisionHigh
+)
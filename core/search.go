@@ -0,0 +1,102 @@
+package core
+
+import "math"
+
+// Refines a root of g, which changes sign between a and b, by bisection.
+func bisect(g func(float64) float64, a, b, ga, tol float64) float64 {
+	for math.Abs(b-a) > tol {
+		m := (a + b) / 2
+		gm := g(m)
+		if gm == 0 {
+			return m
+		}
+		if (ga < 0) == (gm < 0) {
+			a, ga = m, gm
+		} else {
+			b = m
+		}
+	}
+	return (a + b) / 2
+}
+
+// Finds all arguments in range lo..hi where f(x) = target.
+//
+// The range is scanned with a given step, each sign change of f(x) - target
+// is then refined by bisection until the bracket is narrower than tol.
+// The step must be small enough to separate the crossings: two crossings
+// within one step are missed. Results are sorted in ascending order.
+// The step must be positive, otherwise nil is returned.
+func FindAllCrossings(f func(float64) float64, target, lo, hi, step, tol float64) []float64 {
+	if step <= 0 {
+		return nil
+	}
+	g := func(x float64) float64 { return f(x) - target }
+	res := make([]float64, 0)
+	a := lo
+	ga := g(a)
+	if ga == 0 {
+		res = append(res, a)
+	}
+	for a < hi {
+		b := math.Min(a+step, hi)
+		gb := g(b)
+		switch {
+		case gb == 0:
+			res = append(res, b)
+		case ga != 0 && (ga < 0) != (gb < 0):
+			res = append(res, bisect(g, a, b, ga, tol))
+		}
+		a, ga = b, gb
+	}
+	return res
+}
+
+// Golden section search of a minimum of unimodal function f in range a..b.
+func minimize(f func(float64) float64, a, b, tol float64) float64 {
+	gr := (math.Sqrt(5) - 1) / 2
+	c := b - gr*(b-a)
+	d := a + gr*(b-a)
+	fc, fd := f(c), f(d)
+	for math.Abs(b-a) > tol {
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b - gr*(b-a)
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a + gr*(b-a)
+			fd = f(d)
+		}
+	}
+	return (a + b) / 2
+}
+
+// Finds the argument in range lo..hi where f reaches its minimum, or maximum if findMax
+// is true, and the extreme value.
+//
+// The range is scanned with a given step, then the best of the samples is refined by golden
+// section search within one step on each side, until the bracket is narrower than a millionth
+// of the step. The step must be small enough for f to have a single extremum within two steps.
+// An extremum at the boundary of the range is found as well.
+// The step must be positive, otherwise lo and f(lo) are returned.
+func FindExtremum(f func(float64) float64, lo, hi, step float64, findMax bool) (t, value float64) {
+	if step <= 0 {
+		return lo, f(lo)
+	}
+	g := f
+	if findMax {
+		g = func(x float64) float64 { return -f(x) }
+	}
+	best, gbest := lo, g(lo)
+	for x := lo + step; x < hi+step; x += step {
+		x := math.Min(x, hi)
+		if v := g(x); v < gbest {
+			best, gbest = x, v
+		}
+	}
+	t = minimize(g, math.Max(lo, best-step), math.Min(hi, best+step), step*1e-6)
+	if g(t) > gbest {
+		t = best
+	}
+	return t, f(t)
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestFindAllCrossings(t *testing.T) {
+	got := FindAllCrossings(math.Sin, 0, 0.5, 10, 0.1, 1e-9)
+	exp := []float64{math.Pi, 2 * math.Pi, 3 * math.Pi}
+	if len(got) != len(exp) {
+		t.Fatalf("Expected %d crossings, got: %v", len(exp), got)
+	}
+	for i := range exp {
+		if !mathutils.AlmostEqual(got[i], exp[i], 1e-8) {
+			t.Errorf("Expected: %f, got: %f", exp[i], got[i])
+		}
+	}
+}
+
+func TestFindAllCrossingsTarget(t *testing.T) {
+	// sin(x) = 0.5 at pi/6 and 5pi/6
+	got := FindAllCrossings(math.Sin, 0.5, 0, math.Pi, 0.05, 1e-9)
+	exp := []float64{math.Pi / 6, 5 * math.Pi / 6}
+	if len(got) != len(exp) {
+		t.Fatalf("Expected %d crossings, got: %v", len(exp), got)
+	}
+	for i := range exp {
+		if !mathutils.AlmostEqual(got[i], exp[i], 1e-8) {
+			t.Errorf("Expected: %f, got: %f", exp[i], got[i])
+		}
+	}
+}
+
+func TestFindAllCrossingsNone(t *testing.T) {
+	if got := FindAllCrossings(math.Sin, 2, 0, 10, 0.1, 1e-9); len(got) != 0 {
+		t.Errorf("Expected no crossings, got: %v", got)
+	}
+}
+
+func TestFindAllCrossingsBadStep(t *testing.T) {
+	for _, step := range []float64{0, -0.1} {
+		if got := FindAllCrossings(math.Sin, 0, 0.5, 10, step, 1e-9); got != nil {
+			t.Errorf("Expected nil for step %f, got: %v", step, got)
+		}
+	}
+}
+
+func TestFindExtremum(t *testing.T) {
+	// (x - 1.2345)^2 + 3 has minimum 3 at 1.2345
+	f := func(x float64) float64 { return (x-1.2345)*(x-1.2345) + 3 }
+	x, v := FindExtremum(f, -10, 10, 0.5, false)
+	if !mathutils.AlmostEqual(x, 1.2345, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 1.2345, x)
+	}
+	if !mathutils.AlmostEqual(v, 3, 1e-12) {
+		t.Errorf("Expected: %f, got: %f", 3.0, v)
+	}
+	// maximum of -(x + 2)^2 + 1
+	g := func(x float64) float64 { return 1 - (x+2)*(x+2) }
+	x, v = FindExtremum(g, -10, 10, 0.5, true)
+	if !mathutils.AlmostEqual(x, -2, 1e-6) || !mathutils.AlmostEqual(v, 1, 1e-12) {
+		t.Errorf("Expected: -2, 1, got: %f, %f", x, v)
+	}
+	// the maximum of the quadratic on 0..10 is at the boundary
+	if x, _ := FindExtremum(f, 0, 10, 0.3, true); x != 10 {
+		t.Errorf("Expected: 10, got: %f", x)
+	}
+	if x, _ := FindExtremum(f, 2, 10, 0.3, false); !mathutils.AlmostEqual(x, 2, 1e-6) {
+		t.Errorf("Expected: 2, got: %f", x)
+	}
+}
+
+func TestFindExtremumBadStep(t *testing.T) {
+	for _, step := range []float64{0, -0.1} {
+		if x, v := FindExtremum(math.Sin, 0.5, 10, step, true); x != 0.5 || v != math.Sin(0.5) {
+			t.Errorf("Expected: 0.5, %f for step %f, got: %f, %f", math.Sin(0.5), step, x, v)
+		}
+	}
+}
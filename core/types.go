@@ -1,5 +1,11 @@
 package core
 
+import (
+	"math"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
 // Position of a celestial body on the Ecliptic plane
 type EclipticPosition struct {
 	// celestial longitude, degrees
@@ -9,3 +15,60 @@ type EclipticPosition struct {
 	// distance from Earth, A.U.
 	Delta float64
 }
+
+// Rectangular coordinates of the position, in units of Delta.
+// X axis points to the vernal equinox, Z axis to the north pole of the ecliptic.
+func (p EclipticPosition) Rectangular() (x, y, z float64) {
+	sinl, cosl := math.Sincos(mathutils.Radians(p.Lambda))
+	sinb, cosb := math.Sincos(mathutils.Radians(p.Beta))
+	x = p.Delta * cosb * cosl
+	y = p.Delta * cosb * sinl
+	z = p.Delta * sinb
+	return
+}
+
+// Vector sum of two positions. This is a true 3D operation on position vectors,
+// not addition of angles: both positions are converted to rectangular coordinates,
+// added and converted back. Positions must refer to the same equinox and units.
+func (p EclipticPosition) Add(q EclipticPosition) EclipticPosition {
+	x1, y1, z1 := p.Rectangular()
+	x2, y2, z2 := q.Rectangular()
+	return RectangularToSpherical(x1+x2, y1+y2, z1+z2)
+}
+
+// Vector difference of two positions, see [EclipticPosition.Add].
+// If the positions coincide, zero position is returned.
+func (p EclipticPosition) Sub(q EclipticPosition) EclipticPosition {
+	x1, y1, z1 := p.Rectangular()
+	x2, y2, z2 := q.Rectangular()
+	return RectangularToSpherical(x1-x2, y1-y2, z1-z2)
+}
+
+// Ecliptic position with angles in radians, for passing to other math libraries.
+// The rest of the library works in degrees, use [RadiansPosition.Degrees] to convert back.
+type RadiansPosition struct {
+	// celestial longitude, radians
+	Lambda float64
+	// celestial latitude, radians
+	Beta float64
+	// distance, same units as in the original position
+	Delta float64
+}
+
+// Same position with angles converted to radians.
+func (p EclipticPosition) Radians() RadiansPosition {
+	return RadiansPosition{
+		Lambda: mathutils.Radians(p.Lambda),
+		Beta:   mathutils.Radians(p.Beta),
+		Delta:  p.Delta,
+	}
+}
+
+// Same position with angles converted to degrees.
+func (p RadiansPosition) Degrees() EclipticPosition {
+	return EclipticPosition{
+		Lambda: mathutils.Degrees(p.Lambda),
+		Beta:   mathutils.Degrees(p.Beta),
+		Delta:  p.Delta,
+	}
+}
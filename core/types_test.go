@@ -0,0 +1,62 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func assertPosition(t *testing.T, exp, got EclipticPosition) {
+	if !mathutils.AlmostEqual(got.Lambda, exp.Lambda, 1e-6) {
+		t.Errorf("Expected Lambda: %f, got: %f", exp.Lambda, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Beta, exp.Beta, 1e-6) {
+		t.Errorf("Expected Beta: %f, got: %f", exp.Beta, got.Beta)
+	}
+	if !mathutils.AlmostEqual(got.Delta, exp.Delta, 1e-6) {
+		t.Errorf("Expected Delta: %f, got: %f", exp.Delta, got.Delta)
+	}
+}
+
+func TestAdd(t *testing.T) {
+	// (1, 0, 0) + (0, 1, 0) = (1, 1, 0)
+	p := EclipticPosition{Lambda: 0, Beta: 0, Delta: 1}
+	q := EclipticPosition{Lambda: 90, Beta: 0, Delta: 1}
+	assertPosition(t, EclipticPosition{Lambda: 45, Beta: 0, Delta: 1.414214}, p.Add(q))
+	// (0, 2, 0) + (0, 0, 2) = (0, 2, 2)
+	p = EclipticPosition{Lambda: 90, Beta: 0, Delta: 2}
+	q = EclipticPosition{Lambda: 0, Beta: 90, Delta: 2}
+	assertPosition(t, EclipticPosition{Lambda: 90, Beta: 45, Delta: 2.828427}, p.Add(q))
+}
+
+func TestSub(t *testing.T) {
+	// (1, 0, 0) - (0, 1, 0) = (1, -1, 0)
+	p := EclipticPosition{Lambda: 0, Beta: 0, Delta: 1}
+	q := EclipticPosition{Lambda: 90, Beta: 0, Delta: 1}
+	assertPosition(t, EclipticPosition{Lambda: 315, Beta: 0, Delta: 1.414214}, p.Sub(q))
+	if got := q.Sub(q); got != (EclipticPosition{}) {
+		t.Errorf("Expected zero position, got: %v", got)
+	}
+}
+
+func TestAddSubRoundTrip(t *testing.T) {
+	p := EclipticPosition{Lambda: 113.21563, Beta: -6.68417, Delta: 2.5}
+	q := EclipticPosition{Lambda: 281.5, Beta: 1.2, Delta: 0.98}
+	assertPosition(t, p, p.Add(q).Sub(q))
+}
+
+func TestRadians(t *testing.T) {
+	p := EclipticPosition{Lambda: 180, Beta: -45, Delta: 2.5}
+	got := p.Radians()
+	if !mathutils.AlmostEqual(got.Lambda, math.Pi, 1e-12) {
+		t.Errorf("Expected Lambda: %f, got: %f", math.Pi, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Beta, -math.Pi/4, 1e-12) {
+		t.Errorf("Expected Beta: %f, got: %f", -math.Pi/4, got.Beta)
+	}
+	if got.Delta != p.Delta {
+		t.Errorf("Expected Delta: %f, got: %f", p.Delta, got.Delta)
+	}
+	assertPosition(t, p, got.Degrees())
+}
@@ -1,5 +1,11 @@
 package core
 
+import (
+	"math"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
 // Applies f function to each element of data slice.
 func Map(data []float64, f func(float64) float64) []float64 {
 
@@ -11,3 +17,41 @@ func Map(data []float64, f func(float64) float64) []float64 {
 
 	return res
 }
+
+// Sum of unit vectors pointing to the given directions, arc-degrees,
+// divided by their number.
+func meanVector(longitudes []float64) (x, y float64) {
+	for _, l := range longitudes {
+		s, c := math.Sincos(mathutils.Radians(l))
+		x += c
+		y += s
+	}
+	n := float64(len(longitudes))
+	return x / n, y / n
+}
+
+// Circular mean of longitudes, arc-degrees, in range 0..360.
+//
+// Each longitude is treated as a unit vector and the result is direction of their sum,
+// so that the mean of 359 and 1 is 0, not 180. For an empty slice or for
+// the directions which cancel each other the mean is undefined and NaN is returned.
+func MeanLongitude(longitudes []float64) float64 {
+	x, y := meanVector(longitudes)
+	if len(longitudes) == 0 || math.Hypot(x, y) < 1e-12 {
+		return math.NaN()
+	}
+	return mathutils.ReduceDeg(mathutils.Degrees(math.Atan2(y, x)))
+}
+
+// Circular standard deviation of longitudes, arc-degrees.
+//
+// It is computed as sqrt(-2 ln R), where R is the length of the mean unit vector.
+// For closely grouped values the result is close to ordinary standard deviation.
+func StdDevLongitude(longitudes []float64) float64 {
+	if len(longitudes) == 0 {
+		return math.NaN()
+	}
+	x, y := meanVector(longitudes)
+	r := math.Min(math.Hypot(x, y), 1)
+	return mathutils.Degrees(math.Sqrt(-2 * math.Log(r)))
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestMeanLongitude(t *testing.T) {
+	cases := [...]struct {
+		data []float64
+		exp  float64
+	}{
+		{data: []float64{359, 1}, exp: 0},
+		{data: []float64{350, 10, 0}, exp: 0},
+		{data: []float64{10, 20, 30}, exp: 20},
+		{data: []float64{170, 190}, exp: 180},
+	}
+	for _, test := range cases {
+		got := MeanLongitude(test.data)
+		if d := mathutils.ReduceDeg(got-test.exp+180) - 180; !mathutils.AlmostEqual(d, 0, 1e-9) {
+			t.Errorf("Expected: %f, got: %f", test.exp, got)
+		}
+	}
+}
+
+func TestMeanLongitudeUndefined(t *testing.T) {
+	if got := MeanLongitude([]float64{0, 180}); !math.IsNaN(got) {
+		t.Errorf("Expected NaN, got: %f", got)
+	}
+	if got := MeanLongitude(nil); !math.IsNaN(got) {
+		t.Errorf("Expected NaN, got: %f", got)
+	}
+}
+
+func TestStdDevLongitude(t *testing.T) {
+	if got := StdDevLongitude([]float64{45, 45, 45}); !mathutils.AlmostEqual(got, 0, 1e-6) {
+		t.Errorf("Expected: 0, got: %f", got)
+	}
+	// small spread across zero is close to linear standard deviation
+	a := StdDevLongitude([]float64{359, 1})
+	b := StdDevLongitude([]float64{179, 181})
+	if !mathutils.AlmostEqual(a, 1, 1e-3) {
+		t.Errorf("Expected: 1, got: %f", a)
+	}
+	if !mathutils.AlmostEqual(a, b, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", b, a)
+	}
+}
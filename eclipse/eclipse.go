@@ -0,0 +1,36 @@
+// Classification and circumstances of solar and lunar eclipses.
+//
+// Functions of this package expect moments close to New or Full Moon,
+// which may be found with the moon package. All angles are in arc-degrees.
+package eclipse
+
+// Kind of an eclipse.
+type EclipseType int
+
+const (
+	// no eclipse
+	NoEclipse EclipseType = iota
+	// the Moon passes through the Earth's penumbra only
+	Penumbral
+	// the Moon enters the umbra partially, or the Sun is partially covered by the Moon
+	Partial
+	// the Moon is entirely inside the umbra, or the Sun is entirely covered by the Moon
+	Total
+	// the Moon is too small to cover the Sun entirely and leaves a ring of it visible
+	Annular
+)
+
+func (t EclipseType) String() string {
+	switch t {
+	case Penumbral:
+		return "Penumbral"
+	case Partial:
+		return "Partial"
+	case Total:
+		return "Total"
+	case Annular:
+		return "Annular"
+	default:
+		return "None"
+	}
+}
@@ -0,0 +1,56 @@
+package eclipse
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Enlargement of the Earth's shadow by the atmosphere (Danjon, 1/50)
+const _SHADOW_ENLARGEMENT = 1.02
+
+// Ratio of the Earth's radius at latitude 45 degrees to the equatorial one,
+// which accounts for the flattening of the shadow.
+const _EARTH_SHADOW_RADIUS = 0.99834
+
+// Kind and magnitude of a lunar eclipse at jd, Standard Julian Date, usually the moment
+// of Full Moon or of the greatest eclipse.
+//
+// Angular distance of the Moon from the Earth's shadow axis is compared with the radii
+// of the umbra and the penumbra, found from parallaxes of the Sun and the Moon and
+// the Sun's semi-diameter, with the shadow enlarged by 1/50 for the atmosphere
+// (Meeus, "Astronomical Algorithms", chapter 54).
+//
+// Magnitude is the fraction of the Moon's diameter immersed in the umbra for
+// partial and total eclipses, and in the penumbra for penumbral ones.
+// When there is no eclipse, negative penumbral magnitude is returned.
+// The result refers to the given instant: between eclipse contacts the magnitude
+// is smaller than at the greatest eclipse.
+func LunarEclipseType(jd float64) (kind EclipseType, magnitude float64) {
+	sp := sun.Position(jd, core.PrecisionHigh)
+	mp := moon.Position(jd, core.PrecisionHigh)
+	// distance of the Moon's center from the shadow axis, which points to the anti-Sun
+	cosb := math.Cos(mathutils.Radians(mp.Beta))
+	d := mathutils.Degrees(math.Acos(-cosb * math.Cos(mathutils.Radians(mp.Lambda-sp.Lambda))))
+	pm := moon.Parallax(jd)
+	ps := 8.794 / 3600 / sp.Delta
+	ss := sun.AngularDiameter(jd) / 7200
+	sm := moon.AngularDiameter(jd) / 7200
+	umbra := _SHADOW_ENLARGEMENT * (_EARTH_SHADOW_RADIUS*pm - ss + ps)
+	penumbra := _SHADOW_ENLARGEMENT * (_EARTH_SHADOW_RADIUS*pm + ss + ps)
+	umag := (umbra + sm - d) / (2 * sm)
+	pmag := (penumbra + sm - d) / (2 * sm)
+	switch {
+	case umag >= 1:
+		return Total, umag
+	case umag > 0:
+		return Partial, umag
+	case pmag > 0:
+		return Penumbral, pmag
+	default:
+		return NoEclipse, pmag
+	}
+}
@@ -0,0 +1,40 @@
+package eclipse
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestLunarEclipseType(t *testing.T) {
+	cases := [...]struct {
+		date      julian.CivilDate
+		kind      EclipseType
+		magnitude float64
+	}{
+		// total, 2022 Nov 8, greatest eclipse at 10:59 UT, umbral magnitude 1.359
+		{date: julian.CivilDate{Year: 2022, Month: 11, Day: 8 + (10+59.0/60)/24}, kind: Total, magnitude: 1.359},
+		// partial, 2023 Oct 28, 20:14 UT, umbral magnitude 0.122
+		{date: julian.CivilDate{Year: 2023, Month: 10, Day: 28 + (20+14.0/60)/24}, kind: Partial, magnitude: 0.122},
+		// penumbral, 2023 May 5, 17:23 UT, penumbral magnitude 0.964
+		{date: julian.CivilDate{Year: 2023, Month: 5, Day: 5 + (17+23.0/60)/24}, kind: Penumbral, magnitude: 0.964},
+	}
+	for _, test := range cases {
+		kind, mag := LunarEclipseType(julian.CivilToJulian(test.date))
+		if kind != test.kind {
+			t.Errorf("Expected: %s, got: %s", test.kind, kind)
+		}
+		if !mathutils.AlmostEqual(mag, test.magnitude, 0.03) {
+			t.Errorf("Expected magnitude: %f, got: %f", test.magnitude, mag)
+		}
+	}
+}
+
+func TestLunarEclipseTypeNone(t *testing.T) {
+	// Full Moon of 2024 Jan 25, 17:54 UT
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25 + (17+54.0/60)/24})
+	if kind, mag := LunarEclipseType(jd); kind != NoEclipse || mag > 0 {
+		t.Errorf("Expected no eclipse, got: %s, magnitude: %f", kind, mag)
+	}
+}
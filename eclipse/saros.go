@@ -0,0 +1,54 @@
+package eclipse
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/moon"
+)
+
+// Mean synodic month, days
+const _SYNODIC_MONTH = 29.530588853
+
+// Number of lunations in Saros (about 18 years 11 days) and in Inex (about 29 years less 20 days)
+const (
+	_SAROS = 223
+	_INEX  = 358
+)
+
+// Reference eclipses, Standard Julian Dates of the greatest eclipse, and their Saros series.
+const (
+	// total solar eclipse of 1991 July 11
+	_SOLAR_REF       = 2448449.297
+	_SOLAR_REF_SAROS = 136
+	// total lunar eclipse of 2022 November 8
+	_LUNAR_REF       = 2459891.958
+	_LUNAR_REF_SAROS = 136
+)
+
+// Number of the Saros series of an eclipse at jd, Standard Julian Date, usually
+// the moment of New or Full Moon. The eclipse is considered lunar when the Moon is closer
+// to Full than to New Moon, and solar otherwise. There is no check whether the eclipse occurs.
+//
+// Eclipses separated by Saros, 223 synodic months, belong to the same series, while
+// eclipses separated by Inex, 358 months, belong to neighbouring series. The number of
+// lunations L between the eclipse and the reference one is therefore decomposed
+// as L = 223a + 358b with |a| < 180, since a series spans less than 90 eclipses,
+// and b is added to the series of the reference eclipse. Solar eclipses are counted
+// from the total eclipse of 1991 July 11 (Saros 136) and lunar ones
+// from the total eclipse of 2022 November 8 (Saros 136).
+func SarosNumber(jd float64) int {
+	ref, saros := _SOLAR_REF, _SOLAR_REF_SAROS
+	if a := moon.SynodicAngle(jd); a > 90 && a < 270 {
+		ref, saros = _LUNAR_REF, _LUNAR_REF_SAROS
+	}
+	l := int(math.Round((jd - ref) / _SYNODIC_MONTH))
+	// the only b in 0..222 for which l - 358b is divisible by 223
+	b := 0
+	for (l-_INEX*b)%_SAROS != 0 {
+		b++
+	}
+	a := (l - _INEX*b) / _SAROS
+	// shift along the solution family (a + 358t, b - 223t) to the smallest |a|
+	t := int(math.Round(-float64(a) / _INEX))
+	return saros + b - _SAROS*t
+}
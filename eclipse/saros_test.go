@@ -0,0 +1,26 @@
+package eclipse
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+)
+
+func TestSarosNumber(t *testing.T) {
+	for _, test := range []struct {
+		date julian.CivilDate
+		exp  int
+	}{
+		{julian.CivilDate{Year: 1999, Month: 8, Day: 11.46}, 145},  // total solar
+		{julian.CivilDate{Year: 2017, Month: 8, Day: 21.76}, 145},  // total solar
+		{julian.CivilDate{Year: 2024, Month: 4, Day: 8.76}, 139},   // total solar
+		{julian.CivilDate{Year: 2023, Month: 10, Day: 14.75}, 134}, // annular solar
+		{julian.CivilDate{Year: 2018, Month: 7, Day: 27.85}, 129},  // total lunar
+		{julian.CivilDate{Year: 2019, Month: 1, Day: 21.22}, 134},  // total lunar
+		{julian.CivilDate{Year: 2025, Month: 3, Day: 14.29}, 123},  // total lunar
+	} {
+		if got := SarosNumber(julian.CivilToJulian(test.date)); got != test.exp {
+			t.Errorf("%v, expected: %d, got: %d", test.date, test.exp, got)
+		}
+	}
+}
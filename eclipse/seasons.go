@@ -0,0 +1,91 @@
+package eclipse
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Step of scanning for passages of the Sun through the nodes, days
+const _SEASON_STEP = 10.0
+
+// Interval when the Sun is close to a lunar node, so that eclipses may occur.
+type Season struct {
+	// passage of the Sun through the node, Standard Julian Date
+	Center float64
+	// duration of the season, days
+	Width float64
+	// true if the Sun is at the ascending node of the Moon's orbit
+	Ascending bool
+}
+
+// Distance of the Sun from the mean ascending node of the Moon, arc-degrees, -180..180
+func nodeDistance(jd float64) float64 {
+	d := mathutils.ReduceDeg(sun.Position(jd, core.PrecisionMedium).Lambda - moon.LunarNode(jd, true))
+	if d > 180 {
+		d -= 360
+	}
+	return d
+}
+
+// Major ecliptic limits, arc-degrees: beyond these angular distances of the Sun from a lunar
+// node a solar eclipse at New Moon, or a lunar eclipse at Full Moon, is impossible.
+// Minor limits, within which an eclipse is certain, are 15.4 and 9.5 degrees.
+const (
+	SOLAR_ECLIPTIC_LIMIT = 18.5
+	LUNAR_ECLIPTIC_LIMIT = 12.2
+)
+
+// Checks whether New Moon at jd, Standard Julian Date, may be accompanied by a solar
+// eclipse and Full Moon by a lunar one, comparing the Sun's distance from the nearest
+// lunar node with [SOLAR_ECLIPTIC_LIMIT] and [LUNAR_ECLIPTIC_LIMIT].
+// Phase of the Moon is not checked. This is a fast filter before [SolarEclipseKind]
+// and [LunarEclipseType]; see [IsEclipsePossibleWithin] for custom limits.
+func IsEclipsePossible(jd float64) (solar, lunar bool) {
+	return IsEclipsePossibleWithin(jd, SOLAR_ECLIPTIC_LIMIT, LUNAR_ECLIPTIC_LIMIT)
+}
+
+// Same as [IsEclipsePossible] with given ecliptic limits, arc-degrees,
+// e.g. the minor ones for eclipses which are certain.
+func IsEclipsePossibleWithin(jd, solarLimit, lunarLimit float64) (solar, lunar bool) {
+	d := math.Abs(nodeDistance(jd))
+	d = math.Min(d, 180-d)
+	return d <= solarLimit, d <= lunarLimit
+}
+
+// Eclipse seasons, which centers fall within a civil year, usually two, sometimes three.
+//
+// A season lasts while the Sun is within [SOLAR_ECLIPTIC_LIMIT] of a lunar node, about 36 days.
+// Since the nodes regress, the Sun returns to the same node after an eclipse year
+// of 346.6 days, so that the seasons come earlier every year by about 19 days.
+// Every season contains at least one solar and usually one lunar eclipse.
+func EclipseSeasons(year int) []Season {
+	start := julian.CivilToJulian(julian.CivilDate{Year: year, Month: 1, Day: 1})
+	end := julian.CivilToJulian(julian.CivilDate{Year: year + 1, Month: 1, Day: 1})
+	f := func(t float64) float64 { return math.Sin(mathutils.Radians(nodeDistance(t))) }
+	res := make([]Season, 0, 3)
+	for _, c := range core.FindAllCrossings(f, 0, start, end, _SEASON_STEP, 1e-5) {
+		if c == end {
+			continue
+		}
+		d := nodeDistance(c)
+		// at the descending node the distance is close to 180, shift it to zero
+		shift := 0.0
+		if math.Abs(d) > 90 {
+			shift = 180
+		}
+		g := func(t float64) float64 { return mathutils.ReduceDeg(nodeDistance(t)+shift+180) - 180 }
+		lo := core.FindAllCrossings(g, -SOLAR_ECLIPTIC_LIMIT, c-25, c, 1, 1e-5)
+		hi := core.FindAllCrossings(g, SOLAR_ECLIPTIC_LIMIT, c, c+25, 1, 1e-5)
+		s := Season{Center: c, Ascending: shift == 0}
+		if len(lo) > 0 && len(hi) > 0 {
+			s.Width = hi[0] - lo[len(lo)-1]
+		}
+		res = append(res, s)
+	}
+	return res
+}
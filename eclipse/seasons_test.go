@@ -0,0 +1,59 @@
+package eclipse
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestEclipseSeasons(t *testing.T) {
+	got := EclipseSeasons(2024)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 seasons, got: %d", len(got))
+	}
+	if d := got[1].Center - got[0].Center; !mathutils.AlmostEqual(d, 177, 7) {
+		t.Errorf("Expected seasons about six months apart, got: %f days", d)
+	}
+	if !got[0].Ascending || got[1].Ascending {
+		t.Errorf("Expected ascending and descending nodes, got: %v", got)
+	}
+	// solar eclipses of 2024 April 8 and October 2
+	for i, day := range []julian.CivilDate{{Year: 2024, Month: 4, Day: 8.8}, {Year: 2024, Month: 10, Day: 2.8}} {
+		s := got[i]
+		if !mathutils.AlmostEqual(s.Width, 36, 2) {
+			t.Errorf("Expected width about 36 days, got: %f", s.Width)
+		}
+		if jd := julian.CivilToJulian(day); jd < s.Center-s.Width/2 || jd > s.Center+s.Width/2 {
+			t.Errorf("Expected eclipse of %v within season centered at %s", day, julian.JulianToDateString(s.Center))
+		}
+	}
+	// three seasons in 2029
+	if got := EclipseSeasons(2029); len(got) != 3 {
+		t.Errorf("Expected 3 seasons, got: %d", len(got))
+	}
+}
+
+func TestIsEclipsePossible(t *testing.T) {
+	cases := []struct {
+		date         julian.CivilDate
+		solar, lunar bool
+	}{
+		// New Moon and total solar eclipse of 2024 April 8
+		{julian.CivilDate{Year: 2024, Month: 4, Day: 8.76}, true, true},
+		// Full Moon and partial lunar eclipse of 2024 September 18, about 11 degrees from the node
+		{julian.CivilDate{Year: 2024, Month: 9, Day: 18.11}, true, true},
+		// New Moon of 2024 January 11, far from the nodes
+		{julian.CivilDate{Year: 2024, Month: 1, Day: 11.5}, false, false},
+	}
+	for _, c := range cases {
+		solar, lunar := IsEclipsePossible(julian.CivilToJulian(c.date))
+		if solar != c.solar || lunar != c.lunar {
+			t.Errorf("Expected: %t, %t, got: %t, %t for %v", c.solar, c.lunar, solar, lunar, c.date)
+		}
+	}
+	// with minor limits the lunar eclipse of 2024 September 18 is not certain
+	if _, lunar := IsEclipsePossibleWithin(julian.CivilToJulian(cases[1].date), 15.4, 9.5); lunar {
+		t.Errorf("Expected lunar eclipse to be uncertain")
+	}
+}
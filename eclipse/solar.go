@@ -0,0 +1,97 @@
+package eclipse
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Area of the intersection of two disks with radii r1, r2 and distance d between
+// their centers, in units of the radii squared.
+func overlapArea(r1, r2, d float64) float64 {
+	if d >= r1+r2 {
+		return 0
+	}
+	if d <= math.Abs(r1-r2) {
+		r := math.Min(r1, r2)
+		return math.Pi * r * r
+	}
+	a1 := r1 * r1 * math.Acos((d*d+r1*r1-r2*r2)/(2*d*r1))
+	a2 := r2 * r2 * math.Acos((d*d+r2*r2-r1*r1)/(2*d*r2))
+	k := math.Sqrt((-d + r1 + r2) * (d + r1 - r2) * (d - r1 + r2) * (d + r1 + r2))
+	return a1 + a2 - k/2
+}
+
+// Approximate local circumstances of a solar eclipse at jd, Standard Julian Date,
+// for an observer at geographical longitude lng (negative westwards) and latitude lat,
+// arc-degrees, at sea level.
+//
+// obscuration is the fraction of the Sun's disk area covered by the Moon, 0..1.
+// isTotal is true if the Moon covers the Sun entirely.
+//
+// The disks are treated as flat circles centered at topocentric position of the Moon
+// and geocentric position of the Sun. The Moon's radius is increased for the observer
+// being closer to it than the Earth's center (Meeus, "Astronomical Algorithms", 55.1).
+// The Sun's parallax, up to 9", is ignored. jd is Dynamical Time: Universal Time is
+// about a minute behind, which shifts the Moon by half a minute of arc.
+// Since the theories of the Sun and the Moon are accurate to several seconds of arc,
+// the result is not exact near the contacts and the limits of the central path.
+// The Sun's altitude is not checked, so the eclipse may be below the horizon.
+func SolarLocalCircumstances(jd, lng, lat float64) (obscuration float64, isTotal bool) {
+	nut := core.ComputeNutation(jd)
+	s := sun.EquatorialWithNutation(jd, nut)
+	m := moon.TopocentricWithNutation(jd, core.Observer{Longitude: lng, Latitude: lat}, nut)
+	d := core.AngularSeparation(s, m)
+	rs := sun.AngularDiameter(jd) / 7200
+	rm := moon.AngularDiameter(jd) / 7200 * augmentation(jd, lng, lat, nut)
+	obscuration = overlapArea(rs, rm, d) / (math.Pi * rs * rs)
+	return math.Min(obscuration, 1), rm >= rs && d <= rm-rs
+}
+
+// Ratio of topocentric and geocentric semi-diameters of the Moon for jd, Standard Julian Date,
+// and observer at geographical longitude lng and latitude lat, given nutation for jd,
+// see [core.ComputeNutation].
+func augmentation(jd, lng, lat float64, nut core.Nutation) float64 {
+	geo := moon.EquatorialWithNutation(jd, nut)
+	h := core.EquatorialToHorizontal(nut.SiderealTime(jd, lng)*15-geo.Alpha, geo.Delta, lat).Altitude
+	return 1 + math.Sin(mathutils.Radians(h))*math.Sin(mathutils.Radians(moon.Parallax(jd)))
+}
+
+// Kind and magnitude of a solar eclipse at jd, Standard Julian Date, usually the moment
+// of New Moon or of the greatest eclipse, as seen from the Earth as a whole.
+//
+// The eclipse is central when the axis of the Moon's shadow meets the Earth, i.e. when
+// the geocentric distance between the centers of the Sun and the Moon is smaller than
+// the Moon's parallax. Then it is [Total] if the Moon's apparent diameter is larger than
+// the Sun's, and [Annular] otherwise; magnitude is the ratio of the diameters.
+// The diameters are geocentric: for an observer on the central line the Moon is closer by up
+// to the Earth's radius, so that eclipses with the ratio slightly below 1 may be hybrid,
+// i.e. total in the middle of the path. Otherwise the eclipse is [Partial] if the disks
+// overlap for some observer, and magnitude is the largest fraction of the Sun's diameter
+// covered by the Moon. When there is no eclipse, magnitude is negative.
+// The grazing case, when the axis misses the Earth, but the shadow cone touches it, is
+// treated as partial.
+func SolarEclipseKind(jd float64) (kind EclipseType, magnitude float64) {
+	sp := sun.Position(jd, core.PrecisionHigh)
+	mp := moon.Position(jd, core.PrecisionHigh)
+	cosb := math.Cos(mathutils.Radians(mp.Beta))
+	d := mathutils.Degrees(math.Acos(cosb * math.Cos(mathutils.Radians(mp.Lambda-sp.Lambda))))
+	pm := moon.Parallax(jd)
+	ps := 8.794 / 3600 / sp.Delta
+	ss := sun.AngularDiameter(jd) / 7200
+	sm := moon.AngularDiameter(jd) / 7200
+	if d < _EARTH_SHADOW_RADIUS*(pm-ps) {
+		if sm >= ss {
+			return Total, sm / ss
+		}
+		return Annular, sm / ss
+	}
+	magnitude = (pm - ps + ss + sm - d) / (2 * ss)
+	if magnitude > 0 {
+		return Partial, magnitude
+	}
+	return NoEclipse, magnitude
+}
@@ -0,0 +1,87 @@
+package eclipse
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Delta T in 2024, seconds
+const _DELTA_T = 69.0
+
+// Dynamical Time for a date and Universal Time
+func dynamical(year, month, day, hour, minute int) float64 {
+	ut := float64(hour) + float64(minute)/60 + _DELTA_T/3600
+	return julian.CivilToJulian(julian.CivilDate{Year: year, Month: month, Day: float64(day) + ut/24})
+}
+
+func TestSolarLocalCircumstancesTotal(t *testing.T) {
+	// 2024 April 8, Dallas: totality from 18:40:43 to 18:44:35 UT
+	obs, total := SolarLocalCircumstances(dynamical(2024, 4, 8, 18, 42), -96.80, 32.78)
+	if !total || obs != 1 {
+		t.Errorf("Expected total eclipse, got: %t, obscuration: %f", total, obs)
+	}
+	// partial phase began at 17:23 UT
+	obs, total = SolarLocalCircumstances(dynamical(2024, 4, 8, 17, 42), -96.80, 32.78)
+	if total || obs < 0.05 || obs > 0.3 {
+		t.Errorf("Expected partial eclipse, got: %t, obscuration: %f", total, obs)
+	}
+}
+
+func TestSolarLocalCircumstancesPartial(t *testing.T) {
+	// 2024 April 8, New York, greatest eclipse at 19:25 UT, obscuration 0.90
+	obs, total := SolarLocalCircumstances(dynamical(2024, 4, 8, 19, 25), -74.0, 40.71)
+	if total || !mathutils.AlmostEqual(obs, 0.90, 0.02) {
+		t.Errorf("Expected obscuration: 0.90, got: %f", obs)
+	}
+}
+
+func TestSolarLocalCircumstancesNone(t *testing.T) {
+	// a week before the eclipse
+	if obs, total := SolarLocalCircumstances(dynamical(2024, 4, 1, 18, 42), -96.80, 32.78); obs != 0 || total {
+		t.Errorf("Expected no eclipse, got: %t, obscuration: %f", total, obs)
+	}
+}
+
+func TestOverlapArea(t *testing.T) {
+	// concentric disks, separate disks and half-overlapping equal disks
+	if got := overlapArea(1, 2, 0); !mathutils.AlmostEqual(got, 3.141593, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 3.141593, got)
+	}
+	if got := overlapArea(1, 1, 2.5); got != 0 {
+		t.Errorf("Expected: 0, got: %f", got)
+	}
+	// lens of two unit circles at distance 1: 2pi/3 - sqrt(3)/2
+	if got := overlapArea(1, 1, 1); !mathutils.AlmostEqual(got, 1.228370, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", 1.228370, got)
+	}
+}
+
+func TestSolarEclipseKind(t *testing.T) {
+	cases := []struct {
+		jd   float64
+		kind EclipseType
+		mag  float64
+	}{
+		// 2023 October 14, annular, the Moon is smaller than the Sun
+		{dynamical(2023, 10, 14, 18, 0), Annular, 0.94},
+		// 2024 April 8, total
+		{dynamical(2024, 4, 8, 18, 17), Total, 1.04},
+		// 2022 October 25, partial, magnitude 0.862
+		{dynamical(2022, 10, 25, 11, 0), Partial, 0.862},
+	}
+	for _, c := range cases {
+		kind, mag := SolarEclipseKind(c.jd)
+		if kind != c.kind {
+			t.Errorf("Expected: %s, got: %s", c.kind, kind)
+		}
+		if !mathutils.AlmostEqual(mag, c.mag, 0.01) {
+			t.Errorf("Expected magnitude: %f, got: %f", c.mag, mag)
+		}
+	}
+	// New Moon of 2024 January 11 is far from the node
+	if kind, mag := SolarEclipseKind(dynamical(2024, 1, 11, 11, 57)); kind != NoEclipse || mag >= 0 {
+		t.Errorf("Expected no eclipse, got: %s, magnitude: %f", kind, mag)
+	}
+}
@@ -0,0 +1,123 @@
+// Harness for accuracy regression tests.
+//
+// A golden file is a text file in testdata directory of a package. Lines starting with '#'
+// are comments; every other line holds a Standard Julian Date followed by reference values
+// separated by spaces. [Check] computes values for each date and compares them with
+// the reference ones.
+//
+// Flags of go test:
+//
+//	-golden.tol=X    compare with tolerance X instead of the one set by the test
+//	-golden.update   rewrite golden files with computed values, keeping comments
+package golden
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var (
+	update    = flag.Bool("golden.update", false, "rewrite golden files with computed values")
+	tolerance = flag.Float64("golden.tol", 0, "tolerance of golden tests, overrides the default one")
+)
+
+// Line of a golden file.
+type Row struct {
+	// Standard Julian Date
+	JD float64
+	// reference values, nil for comment lines
+	Values []float64
+	// comment line, including leading '#'
+	Comment string
+}
+
+// Reads golden file at path.
+func Load(path string) ([]Row, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	rows := make([]Row, 0)
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			rows = append(rows, Row{Comment: line})
+			continue
+		}
+		fields := strings.Fields(line)
+		nums := make([]float64, len(fields))
+		for i, s := range fields {
+			if nums[i], err = strconv.ParseFloat(s, 64); err != nil {
+				return nil, fmt.Errorf("%s:%d: %w", path, n, err)
+			}
+		}
+		rows = append(rows, Row{JD: nums[0], Values: nums[1:]})
+	}
+	return rows, scanner.Err()
+}
+
+// Writes rows to golden file at path.
+func Save(path string, rows []Row) error {
+	var b strings.Builder
+	for _, r := range rows {
+		if r.Values == nil {
+			fmt.Fprintln(&b, r.Comment)
+			continue
+		}
+		b.WriteString(strconv.FormatFloat(r.JD, 'f', -1, 64))
+		for _, v := range r.Values {
+			b.WriteByte(' ')
+			b.WriteString(strconv.FormatFloat(v, 'f', -1, 64))
+		}
+		b.WriteByte('\n')
+	}
+	return os.WriteFile(path, []byte(b.String()), 0o644)
+}
+
+// Compares values computed by f for each date of golden file at path with the reference
+// ones. Differences larger than tol, or the value of -golden.tol flag if given, are reported.
+// With -golden.update flag the file is rewritten instead.
+func Check(t *testing.T, path string, tol float64, f func(jd float64) []float64) {
+	t.Helper()
+	rows, err := Load(path)
+	if err != nil {
+		t.Fatalf("Could not load golden file: %v", err)
+	}
+	if *tolerance > 0 {
+		tol = *tolerance
+	}
+	for i, r := range rows {
+		if r.Values == nil {
+			continue
+		}
+		got := f(r.JD)
+		if *update {
+			rows[i].Values = got
+			continue
+		}
+		if len(got) != len(r.Values) {
+			t.Fatalf("%s: expected %d values, got: %d", path, len(r.Values), len(got))
+		}
+		for j, exp := range r.Values {
+			if math.Abs(got[j]-exp) > tol {
+				t.Errorf("%s: JD %f, column %d: expected: %f, got: %f", path, r.JD, j+1, exp, got[j])
+			}
+		}
+	}
+	if *update {
+		if err := Save(path, rows); err != nil {
+			t.Fatalf("Could not update golden file: %v", err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package golden
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.golden")
+	rows := []Row{
+		{Comment: "# jd x y"},
+		{JD: 2451545, Values: []float64{1.5, -0.25}},
+		{JD: 2451545.5, Values: []float64{360, 1e-7}},
+	}
+	if err := Save(path, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, rows) {
+		t.Errorf("Expected: %v, got: %v", rows, got)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.golden")
+	rows := []Row{{JD: 1, Values: []float64{2}}, {JD: 2, Values: []float64{4}}}
+	if err := Save(path, rows); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	Check(t, path, 1e-9, func(jd float64) []float64 { return []float64{jd * 2} })
+}
@@ -0,0 +1,62 @@
+package moon
+
+import (
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+)
+
+// Step of scanning for distance extremes, days
+const _APSIS_STEP = 0.25
+
+// Times of the minimal and maximal geocentric distance of the Moon within the lunation
+// containing jd, Standard Julian Date, i.e. between the preceding and the following New Moons.
+//
+// Perigee and apogee drift along the lunations, so the nearest approach within a lunation
+// may happen at its very beginning or end, in that case the boundary is returned.
+// The extremes of [DistanceKm] are found by scanning with a step of 6 hours,
+// and refined by golden section search.
+func SynodicDistanceExtremes(jd float64) (perigeeTime, apogeeTime float64) {
+	start := NextPhase(jd-_M[3], NewMoon)
+	if start > jd {
+		start = NextPhase(start-_M[3]-1, NewMoon)
+	}
+	end := NextPhase(start+1, NewMoon)
+	perigeeTime, _ = core.FindExtremum(DistanceKm, start, end, _APSIS_STEP, false)
+	apogeeTime, _ = core.FindExtremum(DistanceKm, start, end, _APSIS_STEP, true)
+	return
+}
+
+// Apparent diameter of the Moon at some moment.
+type MoonDiameter struct {
+	// Standard Julian Date
+	JD float64
+	// apparent geocentric diameter, arc-seconds
+	Diameter float64
+}
+
+// The largest and the smallest apparent diameters of the Moon in a civil year
+// and their moments, e.g. for comparison of "supermoon" and "micromoon".
+//
+// The Moon is largest at the closest perigee of the year and smallest at the farthest apogee;
+// the extremes differ by about 12-14 percent: about 33.5' against 29.4'.
+// [AngularDiameter] is scanned with a step of 6 hours and refined by golden section search.
+func DiameterExtremes(year int) (maxDiameter, minDiameter MoonDiameter) {
+	start := julian.CivilToJulian(julian.CivilDate{Year: year, Month: 1, Day: 1})
+	end := julian.CivilToJulian(julian.CivilDate{Year: year + 1, Month: 1, Day: 1})
+	tmax, dmax := core.FindExtremum(AngularDiameter, start, end, _APSIS_STEP, true)
+	tmin, dmin := core.FindExtremum(AngularDiameter, start, end, _APSIS_STEP, false)
+	return MoonDiameter{tmax, dmax}, MoonDiameter{tmin, dmin}
+}
+
+// The largest northern and southern ecliptic latitudes of the Moon, arc-degrees,
+// and their moments, within the draconic month of 27.21222 days after jd, Standard Julian Date.
+//
+// The Moon's orbit is inclined to the ecliptic by 5.15 degrees on average; the inclination
+// oscillates between 4.99 and 5.30 degrees. The extremes are 13.6 days apart on average,
+// though perturbations change the interval by more than a day. They occur roughly
+// half-way between passages through the nodes. maxSouth is negative.
+func LatitudeExtremes(jd float64) (maxNorth, maxSouth, timeNorth, timeSouth float64) {
+	timeNorth, maxNorth = core.FindExtremum(Latitude, jd, jd+_M[4], _APSIS_STEP, true)
+	timeSouth, maxSouth = core.FindExtremum(Latitude, jd, jd+_M[4], _APSIS_STEP, false)
+	return
+}
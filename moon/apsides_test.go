@@ -0,0 +1,78 @@
+package moon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestSynodicDistanceExtremes(t *testing.T) {
+	// lunation from 2016 Oct 30 to Nov 29
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 10})
+	perigee, apogee := SynodicDistanceExtremes(jd)
+	// perigee of 2016 Nov 14, 11:23 UT, 356509 km
+	exp := julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 14 + (11+23.0/60)/24})
+	if !mathutils.AlmostEqual(perigee, exp, 1.0/24) {
+		t.Errorf("Expected perigee: %s, got: %s", julian.JulianToDateString(exp), julian.JulianToDateString(perigee))
+	}
+	// apogee of 2016 Oct 31, 19:29 UT, 406662 km, is farther than the next one of Nov 27
+	exp = julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 10, Day: 31 + (19+29.0/60)/24})
+	if !mathutils.AlmostEqual(apogee, exp, 3.0/24) {
+		t.Errorf("Expected apogee: %s, got: %s", julian.JulianToDateString(exp), julian.JulianToDateString(apogee))
+	}
+	// any moment of the lunation gives the same result
+	p2, a2 := SynodicDistanceExtremes(julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 28}))
+	if !mathutils.AlmostEqual(p2, perigee, 1e-4) || !mathutils.AlmostEqual(a2, apogee, 1e-4) {
+		t.Errorf("Expected the same extremes, got: %f, %f", p2, a2)
+	}
+}
+
+func TestDiameterExtremes(t *testing.T) {
+	// 2024: the closest perigee on March 10, 356895 km; the farthest apogee on October 2, 406516 km
+	maxd, mind := DiameterExtremes(2024)
+	perigee := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 10.29})
+	apogee := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 10, Day: 2.82})
+	if !mathutils.AlmostEqual(maxd.JD, perigee, 0.1) {
+		t.Errorf("Expected: %s, got: %s", julian.JulianToDateString(perigee), julian.JulianToDateString(maxd.JD))
+	}
+	if !mathutils.AlmostEqual(mind.JD, apogee, 0.1) {
+		t.Errorf("Expected: %s, got: %s", julian.JulianToDateString(apogee), julian.JulianToDateString(mind.JD))
+	}
+	for _, c := range []struct {
+		d  MoonDiameter
+		km float64
+	}{{maxd, 356895}, {mind, 406516}} {
+		if got := DistanceKm(c.d.JD); !mathutils.AlmostEqual(got, c.km, 50) {
+			t.Errorf("Expected: %f, got: %f", c.km, got)
+		}
+		if got := AngularDiameter(c.d.JD); got != c.d.Diameter {
+			t.Errorf("Expected: %f, got: %f", got, c.d.Diameter)
+		}
+	}
+	if r := maxd.Diameter / mind.Diameter; r < 1.12 || r > 1.15 {
+		t.Errorf("Unexpected ratio of diameters: %f", r)
+	}
+}
+
+func TestLatitudeExtremes(t *testing.T) {
+	for _, day := range []float64{1, 60, 120} {
+		jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: day})
+		north, south, tn, ts := LatitudeExtremes(jd)
+		if north < 4.95 || north > 5.35 {
+			t.Errorf("Expected northern extreme about 5.15, got: %f", north)
+		}
+		if south > -4.95 || south < -5.35 {
+			t.Errorf("Expected southern extreme about -5.15, got: %f", south)
+		}
+		if d := math.Abs(tn - ts); !mathutils.AlmostEqual(d, 13.6, 1.5) {
+			t.Errorf("Expected extremes about 13.6 days apart, got: %f", d)
+		}
+		for _, x := range []float64{tn, ts} {
+			if x < jd || x > jd+_M[4] {
+				t.Errorf("Expected moment within the draconic month, got: %s", julian.JulianToDateString(x))
+			}
+		}
+	}
+}
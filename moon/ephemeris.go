@@ -0,0 +1,68 @@
+package moon
+
+import (
+	"errors"
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+)
+
+// Returned by [InterpolatePosition] when the table is too short for interpolation.
+var ErrShortTable = errors.New("at least 4 rows are required for interpolation")
+
+// Row of the Moon's ephemeris: apparent geocentric right ascension and declination
+// at a given moment.
+type EphemRow struct {
+	// Standard Julian Date
+	JD float64
+	core.EquatorialPosition
+}
+
+// Apparent geocentric positions of the Moon, see [Equatorial], at every hour
+// from jd0, Standard Julian Date, through jd0 + hours / 24 inclusive, i.e. hours + 1 rows.
+// Intermediate positions may be found with [InterpolatePosition].
+func HourlyEphemeris(jd0 float64, hours int) []EphemRow {
+	rows := make([]EphemRow, hours+1)
+	for i := range rows {
+		jd := jd0 + float64(i)/24
+		rows[i] = EphemRow{JD: jd, EquatorialPosition: Equatorial(jd)}
+	}
+	return rows
+}
+
+// Bessel's interpolation formula up to the third differences, i.e. a cubic through
+// values y at n = -1, 0, 1, 2 (Meeus, "Astronomical Algorithms", chapter 3).
+func bessel(y [4]float64, n float64) float64 {
+	d1 := y[2] - y[1]                     // first difference between y0 and y1
+	d2 := (y[3] - y[2] - y[1] + y[0]) / 2 // mean of second differences at y0 and y1
+	d3 := y[3] - 3*y[2] + 3*y[1] - y[0]   // third difference
+	return y[1] + n*d1 + n*(n-1)/2*d2 + n*(n-1)*(n-0.5)/6*d3
+}
+
+// Position of the Moon at jd, Standard Julian Date, interpolated from a table of
+// equally spaced rows, e.g. returned by [HourlyEphemeris].
+//
+// Four rows around jd are used with Bessel's formula, so that for the hourly
+// ephemeris the error does not exceed 0.01". Near the edges of the table
+// the nearest four rows are used, outside the table the result is extrapolated.
+// If there are less than 4 rows, [ErrShortTable] is returned.
+func InterpolatePosition(rows []EphemRow, jd float64) (core.EquatorialPosition, error) {
+	if len(rows) < 4 {
+		return core.EquatorialPosition{}, ErrShortTable
+	}
+	step := rows[1].JD - rows[0].JD
+	i := int(math.Floor((jd - rows[0].JD) / step))
+	k := min(max(i-1, 0), len(rows)-4)
+	n := (jd - rows[k+1].JD) / step
+	var ra, dec [4]float64
+	for j := range ra {
+		row := rows[k+j]
+		ra[j] = row.Alpha
+		if j > 0 {
+			// remove jumps from 360 to 0
+			ra[j] = ra[j-1] + reduceDeg(row.Alpha-ra[j-1]+180) - 180
+		}
+		dec[j] = row.Delta
+	}
+	return core.EquatorialPosition{Alpha: reduceDeg(bessel(ra, n)), Delta: bessel(dec, n)}, nil
+}
@@ -0,0 +1,36 @@
+package moon
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+)
+
+func TestHourlyEphemeris(t *testing.T) {
+	// right ascension of the Moon passes 0 on 2024 March 11, about 00:40 UT
+	jd0 := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 10.75})
+	rows := HourlyEphemeris(jd0, 48)
+	if len(rows) != 49 {
+		t.Fatalf("Expected 49 rows, got: %d", len(rows))
+	}
+	for i, row := range rows {
+		if exp := Equatorial(row.JD); row.EquatorialPosition != exp {
+			t.Errorf("Row %d, expected: %v, got: %v", i, exp, row.EquatorialPosition)
+		}
+	}
+	// mid-hour values, including the first and the last intervals
+	for i := 0; i < 48; i++ {
+		jd := jd0 + (float64(i)+0.5)/24
+		got, err := InterpolatePosition(rows, jd)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if d := core.AngularSeparation(got, Equatorial(jd)) * 3600; d > 0.01 {
+			t.Errorf("Hour %d, expected error below 0.01\", got: %f", i, d)
+		}
+	}
+	if _, err := InterpolatePosition(rows[:3], jd0); err != ErrShortTable {
+		t.Errorf("Expected ErrShortTable, got: %v", err)
+	}
+}
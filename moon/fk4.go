@@ -0,0 +1,23 @@
+package moon
+
+import (
+	"github.com/skrushinsky/kepler/coord"
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+)
+
+// Geocentric equatorial position of the Moon for jd, Standard Julian Date, referred to
+// the mean equator and equinox of B1950.0 in FK4 system, for comparison with old
+// occultation records and catalogs. Angles in arc-degrees.
+//
+// Position for the mean equinox of date is precessed to J2000 (FK5) and transformed
+// to FK4, including the E-terms of aberration, see [coord.FK5ToFK4].
+// Nutation is not applied, since the catalog positions are mean ones.
+// Beyond the validity window of [core.MeanObliquity] [core.ErrOutOfRange] is returned
+// along with the position found with the clamped obliquity.
+func ApparentB1950(jd float64) (core.EquatorialPosition, error) {
+	pos, _, _ := TruePosition(jd)
+	eps, err := core.MeanObliquity(jd)
+	equ := core.EclipticToEquatorial(pos, eps)
+	return coord.FK5ToFK4(coord.Precess(equ, jd, julian.J2000)), err
+}
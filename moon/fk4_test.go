@@ -0,0 +1,52 @@
+package moon
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/kepler/coord"
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+)
+
+func TestApparentB1950(t *testing.T) {
+	for _, date := range []julian.CivilDate{
+		{Year: 1950, Month: 1, Day: 1},
+		{Year: 1969, Month: 7, Day: 20},
+		{Year: 1987, Month: 4, Day: 10},
+	} {
+		jd := julian.CivilToJulian(date)
+		got, err := ApparentB1950(jd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// direct precession to B1950 differs from FK4 position by the equinox
+		// correction and E-terms, less than 1.5 arc-seconds
+		pos, _, _ := TruePosition(jd)
+		eps, _ := core.MeanObliquity(jd)
+		equ := core.EclipticToEquatorial(pos, eps)
+		exp := coord.Precess(equ, jd, core.BesselianToJulian(1950))
+		if d := core.AngularSeparation(got, exp) * 3600; d > 1.5 {
+			t.Errorf("%v: expected: %v, got: %v, difference: %f\"", date, exp, got, d)
+		}
+	}
+}
+
+func TestApparentB1950Meeus(t *testing.T) {
+	// Meeus, "Astronomical Algorithms", example 47.a, 1992 April 12, 0h TD:
+	// λ = 133.162655, β = -3.229126, reduced to B1950.0 with Newcomb's precession.
+	// The difference includes a few arc-seconds between the lunar theories.
+	exp := core.EquatorialPosition{Alpha: 134.100927, Delta: 13.934066}
+	got, err := ApparentB1950(2448724.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := core.AngularSeparation(got, exp) * 3600; d > 10 {
+		t.Errorf("expected: %v, got: %v, difference: %f\"", exp, got, d)
+	}
+}
+
+func TestApparentB1950OutOfRange(t *testing.T) {
+	if _, err := ApparentB1950(julian.J2000 + 2e4*365.25); err != core.ErrOutOfRange {
+		t.Errorf("Expected: %v, got: %v", core.ErrOutOfRange, err)
+	}
+}
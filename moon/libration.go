@@ -0,0 +1,47 @@
+package moon
+
+import (
+	"math"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Inclination of the mean lunar equator to the ecliptic, arc-degrees
+const _LUNAR_EQUATOR_INCLINATION = 1.54242
+
+// Optical libration of the Moon in longitude and latitude, arc-degrees, for jd,
+// Standard Julian Date. Together they are selenographic coordinates of
+// the sub-Earth point, i.e. the center of the lunar disk as seen from the Earth.
+// Positive l means that features near the eastern limb (e.g. Mare Crisium) are turned
+// towards the Earth, positive b means the same for the northern limb.
+//
+// Physical libration, which does not exceed 0.04 degrees, is ignored.
+// Meeus, "Astronomical Algorithms", 53.1.
+func Libration(jd float64) (l, b float64) {
+	t := (jd - julian.J2000) / julian.DAYS_PER_CENT
+	pos, _, _ := TruePosition(jd)
+	f := polynome(t, MoonOrbit["F"]...)
+	w := radians(pos.Lambda - LunarNode(jd, true))
+	beta := radians(pos.Beta)
+	sini, cosi := math.Sincos(radians(_LUNAR_EQUATOR_INCLINATION))
+	sinw, cosw := math.Sincos(w)
+	sinb, cosb := math.Sincos(beta)
+	a := math.Atan2(sinw*cosb*cosi-sinb*sini, cosw*cosb)
+	l = mathutils.ReduceDeg(mathutils.Degrees(a)-f+180) - 180
+	b = mathutils.Degrees(math.Asin(-sinw*cosb*sini - sinb*cosi))
+	return
+}
+
+// Returns true if a point of the lunar surface with given selenographic latitude
+// and longitude, arc-degrees, is on the hemisphere facing the Earth at jd,
+// Standard Julian Date. Longitude is positive towards Mare Crisium (east).
+//
+// Due to libration, features lying within about 8 degrees beyond the mean limb
+// (longitude ±90) periodically become visible.
+func IsVisible(jd, lat, lng float64) bool {
+	l, b := Libration(jd)
+	p1, p2 := radians(b), radians(lat)
+	cosd := math.Sin(p1)*math.Sin(p2) + math.Cos(p1)*math.Cos(p2)*math.Cos(radians(lng-l))
+	return cosd > 0
+}
@@ -0,0 +1,46 @@
+package moon
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestLibration(t *testing.T) {
+	// Meeus, example 53.a, 1992 April 12, 0h TD
+	l, b := Libration(2448724.5)
+	if !mathutils.AlmostEqual(l, -1.206, 0.02) {
+		t.Errorf("Expected l: %f, got: %f", -1.206, l)
+	}
+	if !mathutils.AlmostEqual(b, 4.194, 0.02) {
+		t.Errorf("Expected b: %f, got: %f", 4.194, b)
+	}
+}
+
+func TestIsVisible(t *testing.T) {
+	// find extremes of libration in longitude during a month
+	start := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	east, west := start, start
+	maxL, minL := -90.0, 90.0
+	for jd := start; jd < start+30; jd += 0.25 {
+		l, _ := Libration(jd)
+		if l > maxL {
+			maxL, east = l, jd
+		}
+		if l < minL {
+			minL, west = l, jd
+		}
+	}
+	// a feature on the equator slightly behind the eastern limb
+	if !IsVisible(east, 0, 93) {
+		t.Errorf("Expected the feature to be visible at libration %f", maxL)
+	}
+	if IsVisible(west, 0, 93) {
+		t.Errorf("Expected the feature to be hidden at libration %f", minL)
+	}
+	// center of the disk is always visible, the far side center never is
+	if !IsVisible(east, 0, 0) || IsVisible(east, 0, 180) {
+		t.Error("Unexpected visibility of the disk center or far side")
+	}
+}
@@ -3,7 +3,9 @@ package moon
 import (
 	"math"
 
+	"github.com/skrushinsky/kepler/constants"
 	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/sun"
 	"github.com/skrushinsky/scaliger/julian"
 	"github.com/skrushinsky/scaliger/mathutils"
 )
@@ -37,6 +39,8 @@ var MoonOrbit = map[string][]float64{
 	},
 }
 
+// Periods, days: sidereal month, anomalistic year, anomalistic month,
+// synodic month, draconic month and revolution of the lunar node.
 var _M = [...]float64{27.32158213, 365.2596407, 27.55455094, 29.53058868, 27.21222039, 6798.363307}
 
 // Mean anomaly of the Sun
@@ -83,9 +87,18 @@ func LunarNode(jd float64, mean bool) float64 {
 	return reduceDeg(nd)
 }
 
-// True position of the Moon.
-// Given Julian Day, calculates Moon position, horizontal parallax (A.U.) and angular speed, degrees / 24h.
-func TruePosition(jd float64) (pos core.EclipticPosition, parallax, motion float64) {
+// Fundamental arguments of the lunar theory for a given moment.
+// Angles, except the mean longitude, are in radians.
+type lunarArgs struct {
+	ld                    float64 // Moon's mean longitude, arc-degrees
+	ms, md, de, f, n, c   float64
+	e, e2                 float64
+	de2, de3, de4         float64
+	md2, md3, ms2, f2, f3 float64
+}
+
+// Calculates fundamental arguments for jd, Standard Julian Date.
+func newLunarArgs(jd float64) lunarArgs {
 	djd := jd - julian.J1900
 	t := djd / julian.DAYS_PER_CENT
 	t2 := t * t
@@ -124,7 +137,20 @@ func TruePosition(jd float64) (pos core.EclipticPosition, parallax, motion float
 	ms2 := ms + ms
 	f2 := f + f
 	f3 := f2 + f
-	// ecliptic longitude
+
+	return lunarArgs{
+		ld: ld, ms: ms, md: md, de: de, f: f, n: n, c: c, e: e, e2: e2,
+		de2: de2, de3: de3, de4: de4,
+		md2: md2, md3: md3, ms2: ms2, f2: f2, f3: f3,
+	}
+}
+
+// Ecliptic longitude of the Moon, arc-degrees, for mean equinox of date.
+func (a lunarArgs) longitude() float64 {
+	md, ms, de, f := a.md, a.ms, a.de, a.f
+	md2, md3, ms2, f2 := a.md2, a.md3, a.ms2, a.f2
+	de2, de3, de4 := a.de2, a.de3, a.de4
+	e, e2 := a.e, a.e2
 	l := 6.28875*sin(md) +
 		1.274018*sin(de2-md) +
 		6.58309e-1*sin(de2) +
@@ -175,10 +201,15 @@ func TruePosition(jd float64) (pos core.EclipticPosition, parallax, motion float
 		e*5.21e-4*sin(de4-ms) +
 		4.86e-4*sin(md2-de) +
 		e2*7.17e-4*sin(md-ms2)
+	return mathutils.ReduceDeg(a.ld + l)
+}
 
-	pos.Lambda = mathutils.ReduceDeg(ld + l)
-
-	// ecliptic latitude
+// Ecliptic latitude of the Moon, arc-degrees.
+func (a lunarArgs) latitude() float64 {
+	md, ms, de, f := a.md, a.ms, a.de, a.f
+	md2, md3, f3 := a.md2, a.md3, a.f3
+	de2, de4 := a.de2, a.de4
+	e, e2 := a.e, a.e2
 	g := 5.128189*sin(f) +
 		.280606*sin(md+f) +
 		.277693*sin(md-f) +
@@ -224,12 +255,18 @@ func TruePosition(jd float64) (pos core.EclipticPosition, parallax, motion float
 		e*.000317*sin(de2+f-ms+md) +
 		e2*.000306*sin(2*(de-ms)-f) -
 		.000283*sin(md+f3)
-	w1 := .0004664 * cos(n)
-	w2 := .0000754 * cos(c)
-	pos.Beta = g * (1 - w1 - w2)
+	w1 := .0004664 * cos(a.n)
+	w2 := .0000754 * cos(a.c)
+	return g * (1 - w1 - w2)
+}
 
-	// horizontal parallax
-	parallax = .950724 +
+// Horizontal parallax of the Moon, arc-degrees.
+func (a lunarArgs) parallax() float64 {
+	md, ms, de, f := a.md, a.ms, a.de, a.f
+	md2, md3, f2 := a.md2, a.md3, a.f2
+	de2, de4 := a.de2, a.de4
+	e, e2 := a.e, a.e2
+	return .950724 +
 		.051818*cos(md) +
 		.009531*cos(de2-md) +
 		.007843*cos(de2) +
@@ -260,12 +297,14 @@ func TruePosition(jd float64) (pos core.EclipticPosition, parallax, motion float
 		e2*.000026*cos(2*(de-ms)) -
 		.000023*cos(2*(f-de)+md) +
 		e*.000019*cos(de4-ms-md)
+}
 
-	// distance from Earth in A.U.
-	pos.Delta = 8.794 / (parallax * 3600)
-
-	// angular speed
-	motion = 13.176397 +
+// Daily motion of the Moon, arc-degrees per 24h.
+func (a lunarArgs) motion() float64 {
+	md, ms, de := a.md, a.ms, a.de
+	md2, f2 := a.md2, a.f2
+	de2, de4 := a.de2, a.de4
+	return 13.176397 +
 		1.434006*cos(md) +
 		0.280135*cos(de2) +
 		0.251632*cos(de2-md) +
@@ -296,6 +335,155 @@ func TruePosition(jd float64) (pos core.EclipticPosition, parallax, motion float
 		0.001035*cos(de+md) -
 		0.001019*cos(f2+md2) -
 		0.001006*cos(ms+md2)
+}
 
+// True position of the Moon.
+// Given Julian Day, calculates Moon position, horizontal parallax (A.U.) and angular speed, degrees / 24h.
+func TruePosition(jd float64) (pos core.EclipticPosition, parallax, motion float64) {
+	a := newLunarArgs(jd)
+	pos.Lambda = a.longitude()
+	pos.Beta = a.latitude()
+	parallax = a.parallax()
+	// distance from Earth in A.U.
+	pos.Delta = 8.794 / (parallax * 3600)
+	motion = a.motion()
 	return
 }
+
+// Ecliptic longitude of the Moon, arc-degrees, for jd, Standard Julian Date,
+// referred to the mean equinox of date. Same as Lambda of [TruePosition].
+func Longitude(jd float64) float64 {
+	return newLunarArgs(jd).longitude()
+}
+
+// Ecliptic latitude of the Moon, arc-degrees, for jd, Standard Julian Date.
+// Same as Beta of [TruePosition].
+func Latitude(jd float64) float64 {
+	return newLunarArgs(jd).latitude()
+}
+
+// Equatorial horizontal parallax of the Moon, arc-degrees, for jd, Standard Julian Date.
+// Same as parallax of [TruePosition].
+func Parallax(jd float64) float64 {
+	return newLunarArgs(jd).parallax()
+}
+
+// Daily motion of the Moon in longitude, arc-degrees per 24h, for jd, Standard Julian Date.
+// Same as motion of [TruePosition].
+func DailyMotion(jd float64) float64 {
+	return newLunarArgs(jd).motion()
+}
+
+// Synodic angle, or "age of the Moon in degrees": difference between apparent
+// longitudes of the Moon and the Sun, arc-degrees, in range 0..360.
+// jd is a Standard Julian Date.
+//
+// 0 corresponds to New Moon, 90 to First Quarter, 180 to Full Moon and 270 to Last Quarter.
+// Nutation affects both longitudes equally, so it is omitted.
+func SynodicAngle(jd float64) float64 {
+	pos, _, _ := TruePosition(jd)
+	return synodicAngle(jd, pos.Lambda)
+}
+
+// Synodic angle for jd, Standard Julian Date, given lambda, the Moon's longitude.
+func synodicAngle(jd, lambda float64) float64 {
+	t := (jd - julian.J1900) / julian.DAYS_PER_CENT
+	lsn, _ := sun.TrueGeocentric(t, sun.MeanAnomaly(t), sun.MeanLongitude(t))
+	return reduceDeg(lambda - (lsn - sun.ABERRATION))
+}
+
+// Returns true if the Moon is waxing at jd, Standard Julian Date, i.e. its elongation
+// from the Sun increases from 0 (New Moon) to 180 (Full Moon). From Full to New
+// Moon the elongation decreases and the Moon is waning. See [SynodicAngle].
+func IsWaxing(jd float64) bool {
+	return SynodicAngle(jd) < 180
+}
+
+// Age of the Moon within the draconic (nodical) month, days, for jd, Standard Julian Date.
+//
+// It is measured from the Moon's passage through the ascending node and found
+// from the mean argument of latitude F, therefore it may differ from the true
+// node crossing by up to half a day due to the Moon's equation of the center.
+// The draconic month is 27.21222 days.
+func DraconicAge(jd float64) float64 {
+	t := (jd - julian.J2000) / julian.DAYS_PER_CENT
+	f := reduceDeg(polynome(t, MoonOrbit["F"]...))
+	return f / 360 * _M[4]
+}
+
+// Position of the Moon n sidereal months after jd, Standard Julian Date, see [TruePosition].
+// n may be fractional or negative. Sidereal month of 27.32158 days is the period
+// of the Moon's return to the same longitude, which, however, may differ by a few
+// degrees because of the perturbations.
+func PositionAfterSiderealMonths(jd, n float64) core.EclipticPosition {
+	pos, _, _ := TruePosition(jd + n*_M[0])
+	return pos
+}
+
+// Position of the Moon n synodic months after jd, Standard Julian Date, see [TruePosition].
+// n may be fractional or negative. Synodic month of 29.53059 days is the mean period
+// of the Moon's phases, e.g. 223 synodic months make a Saros.
+func PositionAfterSynodicMonths(jd, n float64) core.EclipticPosition {
+	pos, _, _ := TruePosition(jd + n*_M[3])
+	return pos
+}
+
+// Geocentric distance of the Moon, km, for jd, Standard Julian Date.
+// See Delta of [TruePosition] for the distance in A.U.
+func DistanceKm(jd float64) float64 {
+	return 8.794 / (Parallax(jd) * 3600) * constants.AU
+}
+
+// Geocentric distance of the Moon in units of the Earth's equatorial radius,
+// for jd, Standard Julian Date. Mean value is about 60.3.
+func DistanceEarthRadii(jd float64) float64 {
+	return DistanceKm(jd) / constants.EARTH_RADIUS
+}
+
+// Apparent geocentric angular diameter of the Moon, arc-seconds, for jd, Standard Julian Date.
+func AngularDiameter(jd float64) float64 {
+	pos, _, _ := TruePosition(jd)
+	return core.AngularDiameter(constants.MOON_RADIUS, pos.Delta*constants.AU)
+}
+
+// Position of the Moon computed with a few principal terms of the series.
+func (a lunarArgs) lowPrecisionPosition() core.EclipticPosition {
+	l := a.ld +
+		6.28875*sin(a.md) +
+		1.274018*sin(a.de2-a.md) +
+		6.58309e-1*sin(a.de2) +
+		2.13616e-1*sin(a.md2) -
+		a.e*1.85596e-1*sin(a.ms) -
+		1.14336e-1*sin(a.f2)
+	b := 5.128189*sin(a.f) +
+		.280606*sin(a.md+a.f) +
+		.277693*sin(a.md-a.f) +
+		.173238*sin(a.de2-a.f)
+	p := .950724 +
+		.051818*cos(a.md) +
+		.009531*cos(a.de2-a.md) +
+		.007843*cos(a.de2) +
+		.002824*cos(a.md2)
+	return core.EclipticPosition{Lambda: reduceDeg(l), Beta: b, Delta: 8.794 / (p * 3600)}
+}
+
+// Geocentric ecliptic position of the Moon for jd, Standard Julian Date,
+// with a given level of precision. Angles in arc-degrees, distance in A.U.
+//
+//   - [core.PrecisionLow]: six terms in longitude, four in latitude, and a constant
+//     plus four terms in parallax;
+//     errors are up to 0.35 degree in longitude, 0.2 degree in latitude and 0.4% in distance.
+//   - [core.PrecisionMedium]: full series of [TruePosition], mean equinox of date.
+//   - [core.PrecisionHigh]: same, corrected for nutation in longitude, true equinox of date.
+func Position(jd float64, precision core.Precision) core.EclipticPosition {
+	a := newLunarArgs(jd)
+	if precision == core.PrecisionLow {
+		return a.lowPrecisionPosition()
+	}
+	pos := core.EclipticPosition{Lambda: a.longitude(), Beta: a.latitude()}
+	pos.Delta = 8.794 / (a.parallax() * 3600)
+	if precision == core.PrecisionHigh {
+		pos.Lambda = reduceDeg(pos.Lambda + core.ComputeNutation(jd).Dpsi)
+	}
+	return pos
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/internal/golden"
 	"github.com/skrushinsky/scaliger/julian"
 	"github.com/skrushinsky/scaliger/mathutils"
 )
@@ -172,3 +173,156 @@ func TestTrueLunarNode(t *testing.T) {
 		t.Errorf("Expected True Lunar Node: %f, got: %f", exp, got)
 	}
 }
+
+func TestSynodicAngle(t *testing.T) {
+	// First Quarter, 2024 Jan 18, 03:53 UT
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 18 + (3+53.0/60)/24})
+	got := SynodicAngle(jd)
+	if !mathutils.AlmostEqual(got, 90, 0.1) {
+		t.Errorf("Expected: 90, got: %f", got)
+	}
+}
+
+func TestIsWaxing(t *testing.T) {
+	// New Moon, 2024 Jan 11, 11:57 UT
+	newMoon := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 11 + (11+57.0/60)/24})
+	// Full Moon, 2024 Jan 25, 17:54 UT
+	fullMoon := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25 + (17+54.0/60)/24})
+	if !IsWaxing(newMoon + 0.5) {
+		t.Error("Expected the Moon to be waxing after New Moon")
+	}
+	if IsWaxing(fullMoon + 0.5) {
+		t.Error("Expected the Moon to be waning after Full Moon")
+	}
+}
+
+func TestDraconicAge(t *testing.T) {
+	start := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	beta := func(jd float64) float64 {
+		pos, _, _ := TruePosition(jd)
+		return pos.Beta
+	}
+	nodes := core.FindAllCrossings(beta, 0, start, start+28, 0.5, 1e-6)
+	found := false
+	for _, jd := range nodes {
+		if beta(jd-0.1) > 0 {
+			continue // descending node
+		}
+		found = true
+		age := DraconicAge(jd)
+		if age > _M[4]/2 {
+			age -= _M[4]
+		}
+		if !mathutils.AlmostEqual(age, 0, 0.6) {
+			t.Errorf("Expected age: 0, got: %f", age)
+		}
+	}
+	if !found {
+		t.Fatal("Ascending node not found")
+	}
+}
+
+func TestPositionAfterMonths(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	pos, _, _ := TruePosition(jd)
+	// the Moon returns to about the same longitude after a sidereal month
+	for _, n := range []float64{1, -1} {
+		got := PositionAfterSiderealMonths(jd, n)
+		if d := reduceDeg(got.Lambda-pos.Lambda+180) - 180; !mathutils.AlmostEqual(d, 0, 3) {
+			t.Errorf("Expected: %f, got: %f", pos.Lambda, got.Lambda)
+		}
+	}
+	// and to about the same phase after a synodic month
+	got := PositionAfterSynodicMonths(jd, 1)
+	exp := SynodicAngle(jd)
+	if d := reduceDeg(synodicAngle(jd+_M[3], got.Lambda)-exp+180) - 180; !mathutils.AlmostEqual(d, 0, 5) {
+		t.Errorf("Expected: %f, got: %f", exp, exp+d)
+	}
+}
+
+func TestAngularDiameter(t *testing.T) {
+	// Supermoon, perigee of 2016 Nov 14, 11:23 UT, 356509 km
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 14 + (11+23.0/60)/24})
+	got := AngularDiameter(jd)
+	exp := core.AngularDiameter(1737.4, 356509)
+	if !mathutils.AlmostEqual(got, exp, 5) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+}
+
+func TestDistance(t *testing.T) {
+	start := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	sum := 0.0
+	n := 0
+	for jd := start; jd < start+365; jd += 0.5 {
+		sum += DistanceEarthRadii(jd)
+		n++
+	}
+	if got := sum / float64(n); !mathutils.AlmostEqual(got, 60.3, 0.1) {
+		t.Errorf("Expected: %f, got: %f", 60.3, got)
+	}
+	// perigee of 2016 Nov 14, 11:23 UT, 356509 km
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 14 + (11+23.0/60)/24})
+	if got := DistanceKm(jd); !mathutils.AlmostEqual(got, 356509, 50) {
+		t.Errorf("Expected: %f, got: %f", 356509.0, got)
+	}
+}
+
+func TestSeparateSeries(t *testing.T) {
+	for djd := -10000.5; djd < 47000; djd += 3000 {
+		jd := djd + julian.J1900
+		pos, parallax, motion := TruePosition(jd)
+		if got := Longitude(jd); got != pos.Lambda {
+			t.Errorf("Expected Longitude: %f, got: %f", pos.Lambda, got)
+		}
+		if got := Latitude(jd); got != pos.Beta {
+			t.Errorf("Expected Latitude: %f, got: %f", pos.Beta, got)
+		}
+		if got := Parallax(jd); got != parallax {
+			t.Errorf("Expected Parallax: %f, got: %f", parallax, got)
+		}
+		if got := DailyMotion(jd); got != motion {
+			t.Errorf("Expected DailyMotion: %f, got: %f", motion, got)
+		}
+	}
+}
+
+func TestPosition(t *testing.T) {
+	for djd := -10000.5; djd < 47000; djd += 1000 {
+		jd := djd + julian.J1900
+		exp, _, _ := TruePosition(jd)
+		if got := Position(jd, core.PrecisionMedium); got != exp {
+			t.Errorf("Expected: %v, got: %v", exp, got)
+		}
+		high := Position(jd, core.PrecisionHigh)
+		low := Position(jd, core.PrecisionLow)
+		if d := reduceDeg(low.Lambda-high.Lambda+180) - 180; !mathutils.AlmostEqual(d, 0, 0.35) {
+			t.Errorf("Expected Lambda: %f, got: %f", high.Lambda, low.Lambda)
+		}
+		if !mathutils.AlmostEqual(low.Beta, high.Beta, 0.2) {
+			t.Errorf("Expected Beta: %f, got: %f", high.Beta, low.Beta)
+		}
+		if !mathutils.AlmostEqual(low.Delta, high.Delta, high.Delta*4e-3) {
+			t.Errorf("Expected Delta: %f, got: %f", high.Delta, low.Delta)
+		}
+	}
+}
+
+func BenchmarkPositionLow(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Position(julian.J2000+float64(i%36525), core.PrecisionLow)
+	}
+}
+
+func BenchmarkPositionHigh(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Position(julian.J2000+float64(i%36525), core.PrecisionHigh)
+	}
+}
+
+func TestGoldenPosition(t *testing.T) {
+	golden.Check(t, "testdata/position.golden", 1e-9, func(jd float64) []float64 {
+		pos, parallax, motion := TruePosition(jd)
+		return []float64{pos.Lambda, pos.Beta, pos.Delta, parallax, motion}
+	})
+}
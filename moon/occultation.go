@@ -0,0 +1,86 @@
+package moon
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/sun"
+)
+
+// Step of scanning for occultations, days (20 minutes)
+const _OCC_STEP = 1.0 / 72
+
+// Half-width of the interval around the closest approach searched
+// for beginning and end of an occultation, days
+const _OCC_HALF_SPAN = 0.15
+
+// Finds the next occultation of a star by the Moon after jd, Standard Julian Date.
+//
+// ra and dec are apparent right ascension and declination of the star, lng and lat
+// are geographical longitude (negative westwards) and latitude of the observer, arc-degrees.
+// start and end are the moments, Standard Julian Dates, when the star disappears behind
+// the Moon's limb and reappears. Topocentric position of the Moon and its geocentric
+// angular radius are used.
+//
+// Every star is passed by the Moon once a sidereal month, so one sidereal month
+// after jd is scanned. If the Moon misses the star during this time, occurs is false.
+// The Moon's altitude is not checked, so the event may happen below the horizon.
+func NextOccultation(jd, ra, dec, lng, lat float64) (start, end float64, occurs bool) {
+	star := core.EquatorialPosition{Alpha: ra, Delta: dec}
+	// distance between the star and the Moon's limb, negative when the star is hidden
+	gap := func(t float64) float64 {
+		return core.AngularSeparation(Topocentric(t, lng, lat), star) - AngularDiameter(t)/7200
+	}
+	t0, t1 := jd, jd+_OCC_STEP
+	g0, g1 := gap(t0), gap(t1)
+	for t1 < jd+_M[0]+1 {
+		t2 := t1 + _OCC_STEP
+		g2 := gap(t2)
+		if g1 <= g0 && g1 <= g2 {
+			tm, _ := core.FindExtremum(gap, t0, t2, _OCC_STEP, false)
+			if gap(tm) < 0 {
+				starts := core.FindAllCrossings(gap, 0, tm-_OCC_HALF_SPAN, tm, 0.01, 1e-6)
+				ends := core.FindAllCrossings(gap, 0, tm, tm+_OCC_HALF_SPAN, 0.01, 1e-6)
+				if len(starts) > 0 && len(ends) > 0 {
+					return starts[len(starts)-1], ends[0], true
+				}
+			}
+		}
+		t0, t1, g0, g1 = t1, t2, g1, g2
+	}
+	return 0, 0, false
+}
+
+// Time span searched for the closest approach, days, and the step of scanning (1 hour)
+const (
+	_APPROACH_SPAN = 2.0
+	_APPROACH_STEP = 1.0 / 24
+)
+
+// Time of the closest topocentric approach of the Moon to a body within two days after
+// jd, Standard Julian Date, and the minimal angular separation between their centers,
+// arc-degrees. lng and lat are geographical longitude (negative westwards) and latitude
+// of the observer.
+//
+// Topocentric position of the Moon is used, so that the moment differs from that of
+// the geocentric minimum by up to an hour, e.g. for a solar eclipse it gives the maximal
+// phase for the observer. Parallax of the body is ignored. If the separation decreases
+// all the time, the end of the interval is returned.
+//
+// Only the Sun is supported until positions of planets are available; for other bodies,
+// including the Moon itself, NaN time and separation are returned.
+func ClosestApproach(jd float64, body core.Body, lng, lat float64) (time, separation float64) {
+	var position func(float64, core.Nutation) core.EquatorialPosition
+	switch body {
+	case core.Sun:
+		position = sun.EquatorialWithNutation
+	default:
+		return math.NaN(), math.NaN()
+	}
+	obs := core.Observer{Longitude: lng, Latitude: lat}
+	sep := func(t float64) float64 {
+		nut := core.ComputeNutation(t)
+		return core.AngularSeparation(TopocentricWithNutation(t, obs, nut), position(t, nut))
+	}
+	return core.FindExtremum(sep, jd, jd+_APPROACH_SPAN, _APPROACH_STEP, false)
+}
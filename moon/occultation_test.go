@@ -0,0 +1,62 @@
+package moon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestNextOccultation(t *testing.T) {
+	lng, lat := 37.6173, 55.7558
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 20})
+	// a star exactly behind the Moon's center at jd + 5 days
+	mid := jd + 5
+	star := Topocentric(mid, lng, lat)
+	start, end, occurs := NextOccultation(jd, star.Alpha, star.Delta, lng, lat)
+	if !occurs {
+		t.Fatal("Expected occultation")
+	}
+	if start >= mid || end <= mid {
+		t.Errorf("Expected %f to be between start: %f and end: %f", mid, start, end)
+	}
+	if d := (end - start) * 24; d < 0.5 || d > 2 {
+		t.Errorf("Expected duration about 1 hour, got: %f hours", d)
+	}
+}
+
+func TestNextOccultationMissed(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 20})
+	// Polaris is far from the Moon's path
+	if _, _, occurs := NextOccultation(jd, 37.95, 89.26, 37.6173, 55.7558); occurs {
+		t.Error("Unexpected occultation")
+	}
+}
+
+func TestClosestApproach(t *testing.T) {
+	// total solar eclipse of 2024 April 8 in Dallas, mid-totality at 18:42:39 UT
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 4, Day: 7.5})
+	got, sep := ClosestApproach(jd, core.Sun, -96.80, 32.78)
+	exp := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 4, Day: 8 + (18+43.7/60)/24})
+	if !mathutils.AlmostEqual(got, exp, 3.0/1440) {
+		t.Errorf("Expected: %s, got: %s", julian.JulianToDateString(exp), julian.JulianToDateString(got))
+	}
+	if sep > 0.01 {
+		t.Errorf("Expected separation near 0, got: %f", sep)
+	}
+	// geocentric minimum is 25 minutes earlier and the Moon misses the Sun's center by 0.35 degree
+	geo := func(t float64) float64 { return core.AngularSeparation(Equatorial(t), sun.Equatorial(t)) }
+	tg, _ := core.FindExtremum(geo, jd, jd+2, 1.0/24, false)
+	if d := (got - tg) * 1440; d < 15 || d > 35 {
+		t.Errorf("Expected topocentric minimum 25 minutes after geocentric one, got: %f", d)
+	}
+	if g := geo(tg); !mathutils.AlmostEqual(g, 0.35, 0.02) {
+		t.Errorf("Expected: %f, got: %f", 0.35, g)
+	}
+	if got, sep := ClosestApproach(jd, core.Moon, -96.80, 32.78); !math.IsNaN(got) || !math.IsNaN(sep) {
+		t.Errorf("Expected NaN, got: %f, %f", got, sep)
+	}
+}
@@ -0,0 +1,319 @@
+package moon
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/constants"
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Unicode glyphs of the Moon phases as seen from the Northern hemisphere,
+// from New Moon through waxing and waning phases, by 45 degrees of the synodic angle.
+var phaseGlyphs = [...]rune{'🌑', '🌒', '🌓', '🌔', '🌕', '🌖', '🌗', '🌘'}
+
+// Names of the phases corresponding to [phaseGlyphs]
+var phaseNames = [...]string{
+	"New Moon", "Waxing Crescent", "First Quarter", "Waxing Gibbous",
+	"Full Moon", "Waning Gibbous", "Last Quarter", "Waning Crescent",
+}
+
+// Index of the 45-degree sector centered on a phase, for a given synodic angle, arc-degrees.
+func phaseSector(angle float64) int {
+	return int(reduceDeg(angle+22.5)/45) % 8
+}
+
+// Selects a phase glyph for a given synodic angle, arc-degrees.
+func phaseGlyph(angle float64, southernHemisphere bool) rune {
+	i := phaseSector(angle)
+	if southernHemisphere {
+		// the lit limb is on the left side in the Southern hemisphere
+		i = (8 - i) % 8
+	}
+	return phaseGlyphs[i]
+}
+
+// Unicode glyph of the Moon phase for jd, Standard Julian Date, e.g. '🌓' for First Quarter.
+// Each of the 8 glyphs covers 45 degrees of the synodic angle centered on its phase,
+// see [SynodicAngle]. In the Southern hemisphere the Moon is seen upside down,
+// so that waxing crescent looks like '🌘' there.
+func PhaseEmoji(jd float64, southernHemisphere bool) rune {
+	return phaseGlyph(SynodicAngle(jd), southernHemisphere)
+}
+
+// Extreme geocentric distances of the Moon in the 21st century, km
+const (
+	_PERIGEE_MIN = 356375.0
+	_APOGEE_MAX  = 406720.0
+)
+
+// Weight of the phase in [SupermoonScore], the rest is the weight of distance
+const _SUPERMOON_PHASE_WEIGHT = 0.5
+
+// "Supermoon score" for jd, Standard Julian Date, in range 0..1, which allows ranking
+// Full Moons by how "super" they are.
+//
+// It is a weighted sum of two parts, each in range 0..1, with equal weights:
+//   - closeness to Full Moon: (1 - cos(a)) / 2, where a is the synodic angle;
+//   - closeness to perigee: (406720 - d) / (406720 - 356375), where d is the distance
+//     of the Moon, km, and the numbers are extreme lunar distances of the 21st century.
+//
+// Full Moon at the closest perigee scores 1, New Moon at the farthest apogee scores 0.
+func SupermoonScore(jd float64) float64 {
+	pos, _, _ := TruePosition(jd)
+	a := mathutils.Radians(synodicAngle(jd, pos.Lambda))
+	phase := (1 - math.Cos(a)) / 2
+	d := (_APOGEE_MAX - pos.Delta*constants.AU) / (_APOGEE_MAX - _PERIGEE_MIN)
+	d = math.Max(0, math.Min(1, d))
+	return _SUPERMOON_PHASE_WEIGHT*phase + (1-_SUPERMOON_PHASE_WEIGHT)*d
+}
+
+// Rate of change of the Moon-Sun elongation, arc-degrees per day, for jd, Standard Julian Date,
+// i.e. the derivative of [SynodicAngle]. It is the difference between daily motions
+// of the Moon and the Sun. Mean value is 12.19 degrees per day, actual value varies
+// from about 10.8 to 14.4 with the Moon's speed.
+func ElongationRate(jd float64) float64 {
+	return DailyMotion(jd) - sun.DailyMotion(jd)
+}
+
+// Principal phase of the Moon.
+type PhaseType int
+
+const (
+	NewMoon PhaseType = iota
+	FirstQuarter
+	FullMoon
+	LastQuarter
+)
+
+func (p PhaseType) String() string {
+	switch p {
+	case NewMoon:
+		return "New Moon"
+	case FirstQuarter:
+		return "First Quarter"
+	case FullMoon:
+		return "Full Moon"
+	case LastQuarter:
+		return "Last Quarter"
+	default:
+		return "Unknown"
+	}
+}
+
+// Synodic angle of the phase, arc-degrees: 0, 90, 180 or 270.
+func (p PhaseType) Angle() float64 {
+	return float64(p) * 90
+}
+
+// Precision of phase time, days (about 0.1 second)
+const _PHASE_EPS = 1e-6
+
+// Time of the first phase after jd, Standard Julian Date.
+//
+// Starting from an estimate found with the mean synodic motion, the moment when
+// [SynodicAngle] equals the phase angle is refined by Newton's method,
+// with derivative given by [ElongationRate].
+func NextPhase(jd float64, phase PhaseType) float64 {
+	return refinePhase(jd+reduceDeg(phase.Angle()-SynodicAngle(jd))/360*_M[3], phase)
+}
+
+// Refines time of the phase by Newton's method, given its estimate t, Standard Julian Date.
+func refinePhase(t float64, phase PhaseType) float64 {
+	target := phase.Angle()
+	for i := 0; i < 10; i++ {
+		dt := (reduceDeg(SynodicAngle(t)-target+180) - 180) / ElongationRate(t)
+		t -= dt
+		if math.Abs(dt) < _PHASE_EPS {
+			break
+		}
+	}
+	return t
+}
+
+// Time of the first New Moon after jd, Standard Julian Date, found as the moment when
+// apparent longitudes of the Moon and the Sun are equal, see [Position] and [sun.Position]
+// with [core.PrecisionHigh], e.g. for casting a New Moon chart.
+//
+// Nutation shifts both longitudes equally, so that the result is the same as that
+// of [NextPhase] with [NewMoon], within a fraction of a second.
+func ExactNewMoon(jd float64) float64 {
+	diff := func(t float64) float64 {
+		return Position(t, core.PrecisionHigh).Lambda - sun.Position(t, core.PrecisionHigh).Lambda
+	}
+	t := jd + reduceDeg(-diff(jd))/360*_M[3]
+	for i := 0; i < 10; i++ {
+		dt := (reduceDeg(diff(t)+180) - 180) / ElongationRate(t)
+		t -= dt
+		if math.Abs(dt) < _PHASE_EPS {
+			break
+		}
+	}
+	return t
+}
+
+// Finds the first phase after jd, Standard Julian Date, and apparent positions
+// of the Sun and the Moon at this moment, referred to the true equinox of date.
+//
+// At the returned moment, the Moon's longitude differs from the Sun's by the phase angle,
+// e.g. they are equal for New Moon and exactly 180 degrees apart for Full Moon.
+func PhaseChart(jd float64, phase PhaseType) (phaseTime float64, sunPos, moonPos core.EclipticPosition) {
+	phaseTime = NextPhase(jd, phase)
+	sunPos = sun.Position(phaseTime, core.PrecisionHigh)
+	moonPos = Position(phaseTime, core.PrecisionHigh)
+	return
+}
+
+// Illuminated fraction of the Moon's disk, 0..1, for jd, Standard Julian Date.
+//
+// The fraction is found from the phase angle Sun-Moon-Earth, which accounts for
+// the Moon's latitude and distances of both bodies (Meeus, 48.2, 48.3).
+// Since the Moon rarely passes exactly opposite the Sun, at Full Moon
+// the fraction is usually slightly less than 1.
+func IlluminatedFraction(jd float64) float64 {
+	mp, _, _ := TruePosition(jd)
+	sp := sun.Position(jd, core.PrecisionMedium)
+	cospsi := cos(radians(mp.Beta)) * cos(radians(mp.Lambda-sp.Lambda))
+	sinpsi := math.Sqrt(1 - cospsi*cospsi)
+	// phase angle
+	i := math.Atan2(sp.Delta*sinpsi, mp.Delta-sp.Delta*cospsi)
+	return (1 + cos(i)) / 2
+}
+
+// Time of the first moment after jd, Standard Julian Date, when the illuminated
+// fraction of the Moon's disk equals targetFraction, see [IlluminatedFraction].
+//
+// Every fraction occurs twice per lunation: once while the Moon is waxing, from
+// New to Full Moon, and once while it is waning. The waxing flag selects the half
+// of the lunation, within which the fraction changes monotonically, so the
+// moment is found by bisection. When the fraction is not reached, e.g. for 1
+// or 0, the time of Full or New Moon is returned.
+func NextIllumination(jd, targetFraction float64, waxing bool) float64 {
+	begin, end, sign := NewMoon, FullMoon, 1.0
+	if !waxing {
+		begin, end, sign = FullMoon, NewMoon, -1.0
+	}
+	g := func(t float64) float64 {
+		return (IlluminatedFraction(t) - targetFraction) * sign
+	}
+	hi := NextPhase(jd, end)
+	lo := math.Max(jd, NextPhase(hi-_M[3]*2/3, begin))
+	if lo == jd && g(lo) >= 0 {
+		// the target has been passed during the current half of the lunation
+		hi = NextPhase(hi+1, end)
+		lo = NextPhase(hi-_M[3]*2/3, begin)
+	}
+	if g(lo) >= 0 {
+		return lo
+	}
+	if g(hi) < 0 {
+		return hi
+	}
+	for hi-lo > _PHASE_EPS {
+		m := (lo + hi) / 2
+		if g(m) < 0 {
+			lo = m
+		} else {
+			hi = m
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// Moment of the maximal illuminated fraction of the Moon's disk within window days
+// before or after jd, Standard Julian Date, and the fraction, see [IlluminatedFraction].
+//
+// When the interval contains a Full Moon, the result is close to its moment, but may
+// differ from it by up to an hour, since the Moon passes above or below the Earth's shadow.
+// Otherwise the fraction changes monotonically and the end of the interval closer
+// to the Full Moon is returned. The window must be shorter than a week, so that
+// the interval does not contain both New and Full Moon.
+func IlluminationExtreme(jd, window float64) (time, fraction float64) {
+	return core.FindExtremum(IlluminatedFraction, jd-window, jd+window, window, true)
+}
+
+// Phase of the Moon on a day of a calendar, see [MonthlyPhases].
+type DayPhase struct {
+	// local midnight, Standard Julian Date
+	JD float64
+	// illuminated fraction of the disk at midnight
+	Fraction float64
+	// name of the principal phase if it occurs during the day,
+	// otherwise "Waxing Crescent", "Waxing Gibbous", "Waning Gibbous" or "Waning Crescent"
+	Name string
+}
+
+// Phases of the Moon for each day of a civil month, given geographical longitude,
+// lng, arc-degrees, negative westwards. Days start at local mean midnight, see
+// [core.LocalMidnightJD]. For a time zone, pass its offset from UT in hours multiplied by 15.
+//
+// A day is named after a principal phase (e.g. "Full Moon"), if the phase occurs between
+// the midnight and the next one, so that each principal phase falls on one day only.
+// Other days are named after the quarter of the synodic month at midnight.
+func MonthlyPhases(year, month int, lng float64) []DayPhase {
+	first := julian.CivilToJulian(julian.CivilDate{Year: year, Month: month, Day: 1})
+	nextMonth := julian.CivilToJulian(julian.CivilDate{Year: year + month/12, Month: month%12 + 1, Day: 1})
+	res := make([]DayPhase, int(nextMonth-first))
+	jd := core.LocalMidnightJD(year, month, 1, lng)
+	a0 := SynodicAngle(jd)
+	for i := range res {
+		next := jd + 1
+		a1 := SynodicAngle(next)
+		res[i] = DayPhase{JD: jd, Fraction: IlluminatedFraction(jd)}
+		q := int(a0 / 90)
+		// the next principal phase occurs during the day
+		if reduceDeg(float64(q+1)*90-a0) <= reduceDeg(a1-a0) {
+			res[i].Name = PhaseType((q + 1) % 4).String()
+		} else {
+			// intermediate phase between principal ones, see [phaseNames]
+			res[i].Name = phaseNames[2*q+1]
+		}
+		jd, a0 = next, a1
+	}
+	return res
+}
+
+// Reference New Moon of 2000 January 6, Standard Julian Date, which began
+// lunation 953 in Brown's numbering, counted from 1923 January 17.
+const (
+	_LUNATION_REF     = 2451550.09766
+	_LUNATION_REF_NUM = 953
+)
+
+// Summary of the Moon's phase, see [Lunation].
+type LunationInfo struct {
+	// days since the last New Moon
+	Age float64
+	// illuminated fraction of the disk, 0..1
+	Fraction float64
+	// name of the phase, e.g. "Waxing Crescent": the principal phases cover 45 degrees
+	// of the synodic angle centered on them, like glyphs of [PhaseEmoji]
+	Phase string
+	// days to the next New Moon
+	DaysToNewMoon float64
+	// days to the next Full Moon
+	DaysToFullMoon float64
+	// Brown's lunation number: lunation 1 began with New Moon of 1923 January 17
+	Number int
+}
+
+// Age, illumination, phase and number of the lunation for jd, Standard Julian Date.
+//
+// The synodic angle at jd is computed once and gives the estimates of the next
+// New and Full Moons, see [NextPhase]. Each of them and the previous New Moon,
+// estimated one synodic month earlier, is refined by a few iterations of Newton's method.
+func Lunation(jd float64) LunationInfo {
+	angle := SynodicAngle(jd)
+	nextNew := refinePhase(jd+reduceDeg(-angle)/360*_M[3], NewMoon)
+	prevNew := refinePhase(nextNew-_M[3], NewMoon)
+	return LunationInfo{
+		Age:            jd - prevNew,
+		Fraction:       IlluminatedFraction(jd),
+		Phase:          phaseNames[phaseSector(angle)],
+		DaysToNewMoon:  nextNew - jd,
+		DaysToFullMoon: refinePhase(jd+reduceDeg(180-angle)/360*_M[3], FullMoon) - jd,
+		Number:         int(math.Round((prevNew-_LUNATION_REF)/_M[3])) + _LUNATION_REF_NUM,
+	}
+}
@@ -0,0 +1,262 @@
+package moon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestPhaseEmoji(t *testing.T) {
+	// New Moon, 2024 Jan 11, 11:57 UT
+	newMoon := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 11 + (11+57.0/60)/24})
+	north := []rune("🌑🌒🌓🌔🌕🌖🌗🌘")
+	south := []rune("🌑🌘🌗🌖🌕🌔🌓🌒")
+	for i := range north {
+		jd := newMoon + float64(i)*29.530589/8
+		if got := PhaseEmoji(jd, false); got != north[i] {
+			t.Errorf("Octant %d, expected: %c, got: %c", i, north[i], got)
+		}
+		if got := PhaseEmoji(jd, true); got != south[i] {
+			t.Errorf("Octant %d, Southern hemisphere, expected: %c, got: %c", i, south[i], got)
+		}
+	}
+}
+
+func TestPhaseGlyphBoundaries(t *testing.T) {
+	cases := [...]struct {
+		angle float64
+		exp   rune
+	}{
+		{angle: 0, exp: '🌑'},
+		{angle: 22.4, exp: '🌑'},
+		{angle: 22.6, exp: '🌒'},
+		{angle: 337.6, exp: '🌑'},
+		{angle: 337.4, exp: '🌘'},
+		{angle: 180, exp: '🌕'},
+	}
+	for _, test := range cases {
+		if got := phaseGlyph(test.angle, false); got != test.exp {
+			t.Errorf("Angle %f, expected: %c, got: %c", test.angle, test.exp, got)
+		}
+	}
+}
+
+func TestSupermoonScore(t *testing.T) {
+	// Full Moon of 2016 Nov 14, 13:52 UT, two hours after the closest perigee since 1948
+	superMoon := julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 14 + (13+52.0/60)/24})
+	if got := SupermoonScore(superMoon); got < 0.95 || got > 1 {
+		t.Errorf("Expected score near 1, got: %f", got)
+	}
+	// New Moon of 2016 Nov 29, 12:18 UT, a day and a half after apogee
+	microMoon := julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 29 + (12+18.0/60)/24})
+	if got := SupermoonScore(microMoon); got < 0 || got > 0.05 {
+		t.Errorf("Expected score near 0, got: %f", got)
+	}
+}
+
+func TestElongationRate(t *testing.T) {
+	start := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	sum := 0.0
+	n := 0
+	for jd := start; jd < start+10*29.530589; jd += 0.25 {
+		sum += ElongationRate(jd)
+		n++
+	}
+	if got := sum / float64(n); !mathutils.AlmostEqual(got, 12.19, 0.05) {
+		t.Errorf("Expected mean: %f, got: %f", 12.19, got)
+	}
+	// perigee of 2016 Nov 14 and apogee of 2016 Nov 27
+	perigee := ElongationRate(julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 14.47}))
+	apogee := ElongationRate(julian.CivilToJulian(julian.CivilDate{Year: 2016, Month: 11, Day: 27.84}))
+	if perigee < 14 || apogee > 11.5 {
+		t.Errorf("Expected fast elongation at perigee and slow at apogee, got: %f and %f", perigee, apogee)
+	}
+}
+
+func TestNextPhase(t *testing.T) {
+	start := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	// phases of January 2024, Universal Time, and Delta T = 69s
+	cases := [...]struct {
+		phase PhaseType
+		date  julian.CivilDate
+	}{
+		{phase: LastQuarter, date: julian.CivilDate{Year: 2024, Month: 1, Day: 4 + (3+30.0/60)/24}},
+		{phase: NewMoon, date: julian.CivilDate{Year: 2024, Month: 1, Day: 11 + (11+57.0/60)/24}},
+		{phase: FirstQuarter, date: julian.CivilDate{Year: 2024, Month: 1, Day: 18 + (3+53.0/60)/24}},
+		{phase: FullMoon, date: julian.CivilDate{Year: 2024, Month: 1, Day: 25 + (17+54.0/60)/24}},
+	}
+	for _, test := range cases {
+		exp := julian.CivilToJulian(test.date) + 69.0/86400
+		got := NextPhase(start, test.phase)
+		if !mathutils.AlmostEqual(got, exp, 2.0/1440) {
+			t.Errorf("%s, expected: %s, got: %s", test.phase, julian.JulianToDateString(exp), julian.JulianToDateString(got))
+		}
+	}
+}
+
+func TestPhaseChart(t *testing.T) {
+	start := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 1})
+	for _, phase := range []PhaseType{NewMoon, FirstQuarter, FullMoon, LastQuarter} {
+		jd, sunPos, moonPos := PhaseChart(start, phase)
+		if jd < start || jd > start+_M[3] {
+			t.Errorf("%s, expected the first phase after start, got: %s", phase, julian.JulianToDateString(jd))
+		}
+		if got := reduceDeg(moonPos.Lambda - sunPos.Lambda); !mathutils.AlmostEqual(got, phase.Angle(), 1e-5) &&
+			!mathutils.AlmostEqual(got, phase.Angle()+360, 1e-5) {
+			t.Errorf("%s, expected elongation: %f, got: %f", phase, phase.Angle(), got)
+		}
+	}
+}
+
+func TestIlluminatedFraction(t *testing.T) {
+	// Meeus, example 48.a, 1992 April 12, 0h TD
+	got := IlluminatedFraction(2448724.5)
+	if !mathutils.AlmostEqual(got, 0.6786, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", 0.6786, got)
+	}
+}
+
+func TestIlluminatedFractionVectors(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	for day := 0; day < 30; day++ {
+		mp, _, _ := TruePosition(jd + float64(day))
+		sp := sun.Position(jd+float64(day), core.PrecisionMedium)
+		i := mathutils.Radians(core.PhaseAngleVectors(sp, mp))
+		exp := (1 + math.Cos(i)) / 2
+		if got := IlluminatedFraction(jd + float64(day)); !mathutils.AlmostEqual(got, exp, 1e-9) {
+			t.Errorf("Expected: %f, got: %f", exp, got)
+		}
+	}
+}
+
+func TestNextIllumination(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	// a fully lit disk is the Full Moon
+	full := NextPhase(jd, FullMoon)
+	if got := NextIllumination(jd, 1, true); !mathutils.AlmostEqual(got, full, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", full, got)
+	}
+	// 30% lit crescent occurs twice per lunation
+	waxing := NextIllumination(jd, 0.3, true)
+	waning := NextIllumination(jd, 0.3, false)
+	for _, got := range []float64{waxing, waning} {
+		if got <= jd || got > jd+_M[3] {
+			t.Errorf("Expected the first event after %f, got: %f", jd, got)
+		}
+		if k := IlluminatedFraction(got); !mathutils.AlmostEqual(k, 0.3, 1e-6) {
+			t.Errorf("Expected: %f, got: %f", 0.3, k)
+		}
+	}
+	if !IsWaxing(waxing) || IsWaxing(waning) {
+		t.Errorf("Expected waxing and waning Moon at %f and %f", waxing, waning)
+	}
+	// the next one is a lunation later
+	next := NextIllumination(waxing+1, 0.3, true)
+	if !mathutils.AlmostEqual(next-waxing, _M[3], 1) {
+		t.Errorf("Expected about %f days, got: %f", _M[3], next-waxing)
+	}
+}
+
+func TestNextIlluminationLimits(t *testing.T) {
+	// the Moon is waning on 2024 January 1 and waxing on January 15
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	if got, exp := NextIllumination(jd, 0, true), NextPhase(jd, NewMoon); !mathutils.AlmostEqual(got, exp, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+	jd += 14
+	if got, exp := NextIllumination(jd, 1, false), NextPhase(jd, FullMoon); !mathutils.AlmostEqual(got, exp, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+}
+
+func TestIlluminationExtreme(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	full := NextPhase(jd, FullMoon)
+	// the window spans Full Moon
+	got, k := IlluminationExtreme(full-2, 3)
+	if !mathutils.AlmostEqual(got, full, 1.0/24) {
+		t.Errorf("Expected: %s, got: %s", julian.JulianToDateString(full), julian.JulianToDateString(got))
+	}
+	if k < IlluminatedFraction(full) || k > 1 {
+		t.Errorf("Unexpected fraction: %f", k)
+	}
+	// the window ends before Full Moon
+	if got, _ := IlluminationExtreme(full-5, 2); got != full-3 {
+		t.Errorf("Expected: %f, got: %f", full-3, got)
+	}
+}
+
+func TestMonthlyPhases(t *testing.T) {
+	for _, c := range []struct{ year, month, days int }{{2024, 1, 31}, {2024, 2, 29}, {2023, 2, 28}, {2024, 4, 30}, {2024, 12, 31}} {
+		if got := MonthlyPhases(c.year, c.month, 0); len(got) != c.days {
+			t.Errorf("Expected %d days in %d-%02d, got: %d", c.days, c.year, c.month, len(got))
+		}
+	}
+	// 2024 January: Last Quarter on 4, New Moon on 11, First Quarter on 18 at 03:52 UT, Full Moon on 25
+	got := MonthlyPhases(2024, 1, 0)
+	exp := map[int]string{4: "Last Quarter", 11: "New Moon", 18: "First Quarter", 25: "Full Moon"}
+	for i, p := range got {
+		day := i + 1
+		if name, ok := exp[day]; ok {
+			if p.Name != name {
+				t.Errorf("Expected %s on day %d, got: %s", name, day, p.Name)
+			}
+		} else if p.Name != phaseNames[1] && p.Name != phaseNames[3] && p.Name != phaseNames[5] && p.Name != phaseNames[7] {
+			t.Errorf("Expected intermediate phase on day %d, got: %s", day, p.Name)
+		}
+		if !mathutils.AlmostEqual(p.Fraction, IlluminatedFraction(p.JD), 1e-12) {
+			t.Errorf("Expected: %f, got: %f", IlluminatedFraction(p.JD), p.Fraction)
+		}
+	}
+	// in California First Quarter occurs in the evening of January 17
+	if got := MonthlyPhases(2024, 1, -120); got[16].Name != "First Quarter" {
+		t.Errorf("Expected First Quarter on day 17, got: %s", got[16].Name)
+	}
+}
+
+func TestLunation(t *testing.T) {
+	// 2024 January 20, 0h: New Moon on January 11 at 11:57 UT, Full Moon on January 25
+	// at 17:54 UT, New Moon on February 9 at 22:59 UT, lunation 1250
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 20})
+	got := Lunation(jd)
+	if !mathutils.AlmostEqual(got.Age, 8.501, 1e-3) {
+		t.Errorf("Expected age: %f, got: %f", 8.501, got.Age)
+	}
+	if !mathutils.AlmostEqual(got.Fraction, 0.6956, 1e-4) {
+		t.Errorf("Expected fraction: %f, got: %f", 0.6956, got.Fraction)
+	}
+	if got.Phase != "Waxing Gibbous" {
+		t.Errorf("Expected phase: Waxing Gibbous, got: %s", got.Phase)
+	}
+	if !mathutils.AlmostEqual(got.DaysToNewMoon, 20.959, 1e-3) {
+		t.Errorf("Expected days to New Moon: %f, got: %f", 20.959, got.DaysToNewMoon)
+	}
+	if !mathutils.AlmostEqual(got.DaysToFullMoon, 5.747, 1e-3) {
+		t.Errorf("Expected days to Full Moon: %f, got: %f", 5.747, got.DaysToFullMoon)
+	}
+	if got.Number != 1250 {
+		t.Errorf("Expected lunation: 1250, got: %d", got.Number)
+	}
+}
+
+func TestExactNewMoon(t *testing.T) {
+	// New Moon of 2024 January 11, 11:57 UT
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	got := ExactNewMoon(jd)
+	exp := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 11 + (11+58.0/60)/24})
+	if !mathutils.AlmostEqual(got, exp, 2.0/1440) {
+		t.Errorf("Expected: %s, got: %s", julian.JulianToDateString(exp), julian.JulianToDateString(got))
+	}
+	ml := Position(got, core.PrecisionHigh).Lambda
+	sl := sun.Position(got, core.PrecisionHigh).Lambda
+	if d := reduceDeg(ml-sl+180) - 180; !mathutils.AlmostEqual(d, 0, 1e-6) {
+		t.Errorf("Expected equal longitudes, got: %f, %f", ml, sl)
+	}
+	if np := NextPhase(jd, NewMoon); !mathutils.AlmostEqual(got, np, 1e-5) {
+		t.Errorf("Expected: %s, got: %s", julian.JulianToDateString(np), julian.JulianToDateString(got))
+	}
+}
@@ -0,0 +1,202 @@
+package moon
+
+import (
+	"errors"
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+)
+
+// Returned when the Moon does not rise during the requested day.
+var ErrNoRise = errors.New("the Moon does not rise that day")
+
+// Returned when the Moon does not set during the requested day.
+var ErrNoSet = errors.New("the Moon does not set that day")
+
+// Returned when the Moon does not cross the meridian during the requested day.
+var ErrNoTransit = errors.New("the Moon does not transit that day")
+
+// Step of scanning the day for horizon crossings (1 hour) and precision
+// of the crossing time (about 0.1 sec.), days, and maximal number of iterations
+// of transit search.
+const (
+	_SCAN_STEP = 1.0 / 24
+	_TIME_EPS  = 1e-6
+	_MAX_ITER  = 10
+)
+
+// Mean rate of the Moon's hour angle, arc-degrees per day
+const HOUR_ANGLE_RATE = 347.8
+
+// Apparent equatorial position of the Moon for jd, Standard Julian Date, and its local
+// hour angle, arc-degrees, in range -180..180, given geographical longitude lng and
+// latitude lat of the observer at sea level. If topocentric is false, the position
+// is geocentric, i.e. not corrected for parallax.
+func localPosition(jd, lng, lat float64, topocentric bool) (equ core.EquatorialPosition, ha float64) {
+	nut := core.ComputeNutation(jd)
+	if topocentric {
+		equ = TopocentricWithNutation(jd, core.Observer{Longitude: lng, Latitude: lat}, nut)
+	} else {
+		equ = EquatorialWithNutation(jd, nut)
+	}
+	return equ, reduceDeg(nut.SiderealTime(jd, lng)*15-equ.Alpha+180) - 180
+}
+
+// True (airless) horizontal position of the Moon's center for jd, Standard Julian Date,
+// given geographical longitude lng and latitude lat of the observer at sea level.
+// If topocentric is false, the position is geocentric, i.e. not corrected for parallax.
+func horizontal(jd, lng, lat float64, topocentric bool) core.HorizontalPosition {
+	equ, ha := localPosition(jd, lng, lat, topocentric)
+	return core.EquatorialToHorizontal(ha, equ.Delta, lat)
+}
+
+// Finds when the Moon's center crosses altitude h0 during the civil (UT) date of jd,
+// Standard Julian Date. If rising is true, the upward crossing is searched,
+// otherwise the downward one. If the Moon does not cross the altitude
+// in that direction, [ErrNoRise] or [ErrNoSet] is returned.
+func crossing(jd, lng, lat, h0 float64, topocentric, rising bool) (float64, error) {
+	alt := func(t float64) float64 {
+		return horizontal(t, lng, lat, topocentric).Altitude
+	}
+	start := julian.JulianMidnight(jd)
+	for _, t := range core.FindAllCrossings(alt, h0, start, start+1, _SCAN_STEP, _TIME_EPS) {
+		if (alt(t+_TIME_EPS) > alt(t-_TIME_EPS)) == rising {
+			return t, nil
+		}
+	}
+	if rising {
+		return 0, ErrNoRise
+	}
+	return 0, ErrNoSet
+}
+
+// Azimuth of the rising Moon, arc-degrees, measured from the North eastwards,
+// for the civil (UT) date of jd, Standard Julian Date, given geographical
+// longitude (negative westwards) and latitude of the observer, arc-degrees.
+//
+// The azimuth is taken at the moment the Moon's center crosses the geometric
+// (airless) horizon. If topocentric is true, the Moon is seen from the observer's
+// place, otherwise from the Earth's center. Because of the Moon's large parallax
+// the topocentric Moon rises later and the two azimuths differ by about 1 degree
+// at middle latitudes, more at high latitudes and almost none at the equator.
+// If the Moon does not rise that day, [ErrNoRise] is returned.
+func RiseAzimuth(jd, lng, lat float64, topocentric bool) (float64, error) {
+	t, err := crossing(jd, lng, lat, 0, topocentric, true)
+	if err != nil {
+		return 0, err
+	}
+	return horizontal(t, lng, lat, topocentric).Azimuth, nil
+}
+
+// Refraction at the horizon, arc-degrees
+const _HORIZON_REFRACTION = 34.0 / 60
+
+// Point of the Moon's disk, which rising and setting is considered.
+type Limb int
+
+const (
+	// upper edge of the disk, used for standard moonrise and moonset
+	UpperLimb Limb = iota
+	// center of the disk
+	Center
+	// lower edge of the disk
+	LowerLimb
+)
+
+// Altitude of the Moon's center, arc-degrees, when the limb touches the horizon,
+// corrected for refraction, given s, the Moon's semidiameter.
+func (l Limb) horizon(s float64) float64 {
+	switch l {
+	case Center:
+		return -_HORIZON_REFRACTION
+	case LowerLimb:
+		return -_HORIZON_REFRACTION + s
+	default:
+		return -_HORIZON_REFRACTION - s
+	}
+}
+
+// Moonrise and moonset for the civil (UT) date of jd, Standard Julian Date, given
+// geographical longitude (negative westwards) and latitude of the observer, arc-degrees.
+//
+// The moment when a limb of the topocentric Moon touches the horizon, corrected for
+// 34' of refraction, is found. For [UpperLimb], the standard moonrise, the Moon's
+// center is at -34' minus the semidiameter (about 15'), for [Center] at -34', for
+// [LowerLimb] at -34' plus the semidiameter. The upper limb rises first and sets last.
+//
+// The Moon rises about 50 minutes later each day, so once a month
+// it does not rise or does not set during a civil date; then [ErrNoRise] or [ErrNoSet]
+// is returned. Moonset may precede moonrise.
+func RiseSet(jd, lng, lat float64, limb Limb) (rise, set float64, err error) {
+	h0 := limb.horizon(AngularDiameter(jd) / 7200)
+	rise, err = crossing(jd, lng, lat, h0, true, true)
+	if err != nil {
+		return
+	}
+	set, err = crossing(jd, lng, lat, h0, true, false)
+	return
+}
+
+// Same as [RiseSet] for an observer. Elevation is ignored.
+func RiseSetForObserver(jd float64, obs core.Observer, limb Limb) (rise, set float64, err error) {
+	return RiseSet(jd, obs.Longitude, obs.Latitude, limb)
+}
+
+// Apparent and geometric moonrise and moonset, see [RiseSetDetailed].
+type RiseSetDetails struct {
+	// apparent moonrise and moonset of the upper limb, same as returned by [RiseSet]
+	Rise, Set float64
+	// moments when the center of the geocentric Moon crosses the geometric horizon
+	GeometricRise, GeometricSet float64
+}
+
+// Apparent moonrise and moonset of the upper limb for the civil (UT) date of jd,
+// Standard Julian Date, see [RiseSet], along with geometric ones: when the Moon's center seen from
+// the Earth's center crosses the horizon, with no refraction.
+//
+// For the Sun, refraction and semidiameter make the apparent sunrise about
+// 4 minutes earlier than the geometric one. For the Moon they are outweighed by
+// the parallax of about 57', which lowers the Moon. Therefore apparent moonrise
+// usually comes later than the geometric one and apparent moonset earlier,
+// by a minute or two, more at high latitudes.
+func RiseSetDetailed(jd, lng, lat float64) (RiseSetDetails, error) {
+	var res RiseSetDetails
+	var err error
+	if res.Rise, res.Set, err = RiseSet(jd, lng, lat, UpperLimb); err != nil {
+		return res, err
+	}
+	if res.GeometricRise, err = crossing(jd, lng, lat, 0, false, true); err != nil {
+		return res, err
+	}
+	res.GeometricSet, err = crossing(jd, lng, lat, 0, false, false)
+	return res, err
+}
+
+// Upper transit (culmination) of the Moon across the local meridian during the civil (UT)
+// date of jd, Standard Julian Date, given geographical longitude (negative westwards)
+// and latitude of the observer, arc-degrees, and the Moon's true (airless) topocentric
+// altitude at this moment.
+//
+// The moment is refined by iterations, which account for the Moon's motion, so that
+// the transits come about 50 minutes later each day. Therefore once a month there is
+// no transit during a civil date, then [ErrNoTransit] is returned. At high latitudes
+// the Moon may be circumpolar or stay below the horizon all day long, in the latter
+// case the transit exists, but the altitude is negative.
+func Transit(jd, lng, lat float64) (time, altitude float64, err error) {
+	start := julian.JulianMidnight(jd)
+	_, ha := localPosition(start, lng, lat, true)
+	time = start + reduceDeg(-ha)/HOUR_ANGLE_RATE
+	for i := 0; i < _MAX_ITER; i++ {
+		_, ha = localPosition(time, lng, lat, true)
+		dt := -ha / HOUR_ANGLE_RATE
+		time += dt
+		if math.Abs(dt) < _TIME_EPS {
+			break
+		}
+	}
+	if time < start || time >= start+1 {
+		return 0, 0, ErrNoTransit
+	}
+	return time, horizontal(time, lng, lat, true).Altitude, nil
+}
@@ -0,0 +1,110 @@
+package moon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Greenwich Observatory
+const (
+	_GREENWICH_LNG = 0.0
+	_GREENWICH_LAT = 51.4769
+)
+
+func TestRiseAzimuth(t *testing.T) {
+	// Greenwich, 2024 Jan 25: the Moon rises in the North-East
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25})
+	topo, err := RiseAzimuth(jd, _GREENWICH_LNG, _GREENWICH_LAT, true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	geo, err := RiseAzimuth(jd, _GREENWICH_LNG, _GREENWICH_LAT, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if topo < 0 || topo > 90 {
+		t.Errorf("Expected the Moon to rise in the North-East, got: %f", topo)
+	}
+	if d := math.Abs(topo - geo); d < 0.5 || d > 3 {
+		t.Errorf("Expected about 1 degree difference, got: %f", d)
+	}
+}
+
+func TestRiseSet(t *testing.T) {
+	// Greenwich, 2024 Jan 25: Full Moon rises at about 16:00 UT and sets at about 08:20 UT
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25})
+	rise, set, err := RiseSet(jd, _GREENWICH_LNG, _GREENWICH_LAT, UpperLimb)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expRise := jd + 16.0/24
+	expSet := jd + (8+20.0/60)/24
+	if !mathutils.AlmostEqual(rise, expRise, 5.0/1440) {
+		t.Errorf("Expected rise: %s, got: %s", julian.JulianToDateString(expRise), julian.JulianToDateString(rise))
+	}
+	if !mathutils.AlmostEqual(set, expSet, 5.0/1440) {
+		t.Errorf("Expected set: %s, got: %s", julian.JulianToDateString(expSet), julian.JulianToDateString(set))
+	}
+}
+
+func TestRiseSetDetailed(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25})
+	got, err := RiseSetDetailed(jd, _GREENWICH_LNG, _GREENWICH_LAT)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// parallax outweighs refraction and semidiameter
+	if d := (got.Rise - got.GeometricRise) * 1440; d <= 0 || d > 3 {
+		t.Errorf("Expected apparent rise to follow geometric one within 3 minutes, got: %f", d)
+	}
+	if d := (got.GeometricSet - got.Set) * 1440; d <= 0 || d > 3 {
+		t.Errorf("Expected apparent set to precede geometric one within 3 minutes, got: %f", d)
+	}
+}
+
+func TestRiseSetLimb(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25})
+	var rises, sets [3]float64
+	for i, limb := range []Limb{UpperLimb, Center, LowerLimb} {
+		var err error
+		if rises[i], sets[i], err = RiseSet(jd, _GREENWICH_LNG, _GREENWICH_LAT, limb); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// the disk takes a few minutes to cross the horizon
+	for i := 1; i < 3; i++ {
+		if d := (rises[i] - rises[i-1]) * 1440; d <= 0 || d > 5 {
+			t.Errorf("Expected later rise of limb %d, got difference: %f min.", i, d)
+		}
+		if d := (sets[i-1] - sets[i]) * 1440; d <= 0 || d > 5 {
+			t.Errorf("Expected earlier set of limb %d, got difference: %f min.", i, d)
+		}
+	}
+}
+
+func TestTransit(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 22})
+	got, alt, err := Transit(jd, _GREENWICH_LNG, _GREENWICH_LAT)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the waxing gibbous Moon culminates in the evening
+	if got < jd+0.8 || got > jd+1 {
+		t.Errorf("Expected evening transit, got: %s", julian.JulianToDateString(got))
+	}
+	equ, ha := localPosition(got, _GREENWICH_LNG, _GREENWICH_LAT, true)
+	if !mathutils.AlmostEqual(ha, 0, 1e-3) {
+		t.Errorf("Expected hour angle: 0, got: %f", ha)
+	}
+	if exp := 90 - _GREENWICH_LAT + equ.Delta; !mathutils.AlmostEqual(alt, exp, 1e-3) {
+		t.Errorf("Expected altitude: %f, got: %f", exp, alt)
+	}
+	// the Full Moon culminates about midnight: on Jan 24 shortly before, on Jan 26 after it
+	jd = julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25})
+	if _, _, err := Transit(jd, _GREENWICH_LNG, _GREENWICH_LAT); err != ErrNoTransit {
+		t.Errorf("Expected ErrNoTransit, got: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package moon
+
+import (
+	"github.com/skrushinsky/kepler/core"
+)
+
+// Apparent geocentric equatorial position of the Moon for jd, Standard Julian Date,
+// referred to the true equator and equinox of date. Angles in arc-degrees.
+func Equatorial(jd float64) core.EquatorialPosition {
+	return EquatorialWithNutation(jd, core.ComputeNutation(jd))
+}
+
+// Same as [Equatorial], given nutation for jd, see [core.ComputeNutation].
+func EquatorialWithNutation(jd float64, nut core.Nutation) core.EquatorialPosition {
+	pos, _, _ := TruePosition(jd)
+	pos.Lambda += nut.Dpsi
+	return core.EclipticToEquatorial(pos, nut.TrueObliquity(jd))
+}
+
+// Apparent topocentric equatorial position of the Moon for jd, Standard Julian Date,
+// given geographical longitude (negative westwards) and latitude of the observer
+// at sea level, arc-degrees.
+//
+// Due to parallax, it differs from the geocentric position by up to 1 degree.
+func Topocentric(jd, lng, lat float64) core.EquatorialPosition {
+	return TopocentricForObserver(jd, core.Observer{Longitude: lng, Latitude: lat})
+}
+
+// Same as [Topocentric] for an observer, taking the observer's elevation into account.
+func TopocentricForObserver(jd float64, obs core.Observer) core.EquatorialPosition {
+	return TopocentricWithNutation(jd, obs, core.ComputeNutation(jd))
+}
+
+// Same as [TopocentricForObserver], given nutation for jd, see [core.ComputeNutation].
+func TopocentricWithNutation(jd float64, obs core.Observer, nut core.Nutation) core.EquatorialPosition {
+	pos, parallax, _ := TruePosition(jd)
+	pos.Lambda += nut.Dpsi
+	equ := core.EclipticToEquatorial(pos, nut.TrueObliquity(jd))
+	lst := nut.SiderealTime(jd, obs.Longitude)
+	return core.EquatorialToTopocentric(equ, parallax, lst*15-equ.Alpha, obs.Latitude, obs.Elevation)
+}
+
+// Rate of the Moon's apparent geocentric motion in right ascension and declination,
+// arc-seconds per second of time, for jd, Standard Julian Date, relative to the stars.
+//
+// raRate is the rate of change of the right ascension itself; for the motion along
+// the sky it must be multiplied by cos(declination). The Moon moves eastwards by about
+// 0.55" per second, so that a mount tracking at sidereal rate has to lag behind by that much.
+// The rates are found by numeric differentiation of [Equatorial] over one minute.
+func TrackingRate(jd float64) (raRate, decRate float64) {
+	const h = 30.0 // half-step, seconds
+	p1 := Equatorial(jd - h/86400)
+	p2 := Equatorial(jd + h/86400)
+	da := reduceDeg(p2.Alpha-p1.Alpha+180) - 180
+	return da * 3600 / (2 * h), (p2.Delta - p1.Delta) * 3600 / (2 * h)
+}
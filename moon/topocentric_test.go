@@ -0,0 +1,89 @@
+package moon
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+	"github.com/skrushinsky/scaliger/sidereal"
+)
+
+func TestEquatorial(t *testing.T) {
+	// Meeus, example 47.a, 1992 April 12, 0h TD
+	got := Equatorial(2448724.5)
+	if !mathutils.AlmostEqual(got.Alpha, 134.688470, 5e-3) {
+		t.Errorf("Expected Alpha: %f, got: %f", 134.688470, got.Alpha)
+	}
+	if !mathutils.AlmostEqual(got.Delta, 13.768368, 5e-3) {
+		t.Errorf("Expected Delta: %f, got: %f", 13.768368, got.Delta)
+	}
+}
+
+func TestTopocentric(t *testing.T) {
+	lng, lat := 37.6173, 55.7558
+	for _, djd := range []float64{0, 0.2, 0.4, 0.6, 0.8} {
+		jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 20}) + djd
+		geo := Equatorial(jd)
+		topo := Topocentric(jd, lng, lat)
+		_, parallax, _ := TruePosition(jd)
+		lst := sidereal.JulianToSidereal(jd, sidereal.SiderealOptions{Lng: lng})
+		alt := core.EquatorialToHorizontal(lst*15-geo.Alpha, geo.Delta, lat).Altitude
+		// parallax in altitude
+		exp := parallax * math.Cos(mathutils.Radians(alt))
+		got := core.AngularSeparation(geo, topo)
+		if !mathutils.AlmostEqual(got, exp, 0.01) {
+			t.Errorf("Expected shift: %f, got: %f", exp, got)
+		}
+	}
+}
+
+func TestTopocentricForObserver(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25.7})
+	obs := core.Observer{Longitude: 7.66, Latitude: 45.98}
+	if got, exp := TopocentricForObserver(jd, obs), Topocentric(jd, obs.Longitude, obs.Latitude); got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+	// from the top of the Matterhorn the parallax is slightly larger
+	obs.Elevation = 4478
+	got := TopocentricForObserver(jd, obs)
+	exp := Topocentric(jd, obs.Longitude, obs.Latitude)
+	if d := core.AngularSeparation(got, exp) * 3600; d <= 0 || d > 5 {
+		t.Errorf("Expected a few arc-seconds difference, got: %f", d)
+	}
+	rise1, set1, err1 := RiseSetForObserver(jd, obs, UpperLimb)
+	rise2, set2, err2 := RiseSet(jd, obs.Longitude, obs.Latitude, UpperLimb)
+	if rise1 != rise2 || set1 != set2 || err1 != err2 {
+		t.Errorf("Expected: %f, %f, got: %f, %f", rise2, set2, rise1, set1)
+	}
+}
+
+func TestTrackingRate(t *testing.T) {
+	// mean motion of the Moon, 360 degrees per sidereal month, arc-seconds per second
+	mean := 360 * 3600 / (_M[0] * 86400)
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 1})
+	for i := 0; i < 28; i++ {
+		ra, dec := TrackingRate(jd + float64(i))
+		cosd := math.Cos(mathutils.Radians(Equatorial(jd + float64(i)).Delta))
+		got := math.Hypot(ra*cosd, dec)
+		if got < mean*0.75 || got > mean*1.3 {
+			t.Errorf("Expected about %f, got: %f", mean, got)
+		}
+		if ra <= 0 {
+			t.Errorf("Expected eastward motion, got: %f", ra)
+		}
+	}
+}
+
+func TestWithNutation(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25.5})
+	nut := core.ComputeNutation(jd)
+	if got, exp := EquatorialWithNutation(jd, nut), Equatorial(jd); got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+	obs := core.Observer{Longitude: 30.3, Latitude: 59.95, Elevation: 100}
+	if got, exp := TopocentricWithNutation(jd, obs, nut), TopocentricForObserver(jd, obs); got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+}
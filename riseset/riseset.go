@@ -0,0 +1,174 @@
+// Rising, setting and transits of the Sun and the Moon.
+//
+// Geographical longitude is negative westwards, all angles are in arc-degrees.
+package riseset
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/moon"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Step of numeric differentiation of altitude, days (1 minute)
+const _RATE_STEP = 1.0 / 1440
+
+// Mean rate of the hour angle, arc-degrees per day, for the Sun and the Moon
+var _HA_RATE = map[core.Body]float64{core.Sun: 360.0, core.Moon: moon.HOUR_ANGLE_RATE}
+
+// Maximal number of iterations and desired precision (about 0.1 sec.) of time search.
+const (
+	_MAX_ITER = 10
+	_TIME_EPS = 1e-6
+)
+
+// Local hour angle of a body, arc-degrees, in range -180..180, and its true (airless)
+// altitude, arc-degrees, for jd, Standard Julian Date, given geographical longitude lng
+// and latitude lat of the observer and nutation for jd, see [core.ComputeNutation].
+// The Moon's position is topocentric, parallax of the Sun is negligible.
+func hourAngleAltitude(jd, lng, lat float64, body core.Body, nut core.Nutation) (ha, alt float64) {
+	var pos core.EquatorialPosition
+	if body == core.Moon {
+		pos = moon.TopocentricWithNutation(jd, core.Observer{Longitude: lng, Latitude: lat}, nut)
+	} else {
+		pos = sun.EquatorialWithNutation(jd, nut)
+	}
+	ha = mathutils.ReduceDeg(nut.SiderealTime(jd, lng)*15-pos.Alpha+180) - 180
+	return ha, core.EquatorialToHorizontal(ha, pos.Delta, lat).Altitude
+}
+
+// True (airless) altitude of a body, arc-degrees, for jd, Standard Julian Date,
+// given geographical longitude lng and latitude lat of the observer.
+func altitude(jd, lng, lat float64, body core.Body) float64 {
+	_, alt := hourAngleAltitude(jd, lng, lat, body, core.ComputeNutation(jd))
+	return alt
+}
+
+// Rate of change of a body's altitude, arc-degrees per minute of time, for jd,
+// Standard Julian Date, given geographical longitude lng and latitude lat of the observer.
+//
+// Positive value means that the body rises. Near the horizon the rate tells
+// how long it takes the disk to cross it: at the equator the Sun sets at
+// 0.25 degree per minute, at higher latitudes it sets slower.
+// The rate includes the body's own motion and is found by numeric differentiation.
+func AltitudeRate(jd, lng, lat float64, body core.Body) float64 {
+	h1 := altitude(jd-_RATE_STEP, lng, lat, body)
+	h2 := altitude(jd+_RATE_STEP, lng, lat, body)
+	return (h2 - h1) / 2
+}
+
+// Same as [AltitudeRate] for an observer. Elevation is ignored.
+func AltitudeRateForObserver(jd float64, obs core.Observer, body core.Body) float64 {
+	return AltitudeRate(jd, obs.Longitude, obs.Latitude, body)
+}
+
+// Time of the first upper transit of a body across the local meridian after jd,
+// Standard Julian Date, given geographical longitude (negative westwards) and latitude
+// of the observer, and whether the body's true (airless) altitude at the transit
+// reaches minAltitude, arc-degrees.
+//
+// Transit is the highest point of the body's daily path. At high latitudes objects
+// of low declination culminate low: e.g. beyond the polar circle the Sun stays
+// below the horizon at noon around winter solstice, so that no threshold above
+// the horizon is exceeded. The moment is found by iterations, which account
+// for the body's own motion.
+func NextTransitAbove(jd, lng, lat float64, body core.Body, minAltitude float64) (float64, bool) {
+	rate := _HA_RATE[body]
+	ha, _ := hourAngleAltitude(jd, lng, lat, body, core.ComputeNutation(jd))
+	t := jd + mathutils.ReduceDeg(-ha)/rate
+	for i := 0; i < _MAX_ITER; i++ {
+		ha, _ = hourAngleAltitude(t, lng, lat, body, core.ComputeNutation(t))
+		dt := -ha / rate
+		t += dt
+		if math.Abs(dt) < _TIME_EPS {
+			break
+		}
+	}
+	return t, altitude(t, lng, lat, body) >= minAltitude
+}
+
+// Conditions of visibility used by [VisibilityWindows]: the body's true altitude,
+// arc-degrees, and the highest altitude of the Sun, when the sky is dark enough
+// (end of nautical twilight). Time step of scanning the night, days (5 minutes).
+const (
+	_VISIBLE_ALT = 0.0
+	_DARK_ALT    = -12.0
+	_NIGHT_STEP  = 5.0 / 1440
+)
+
+// Interval of a night, when a body may be observed, see [VisibilityWindows].
+type Window struct {
+	// local noon before the night, Standard Julian Date
+	Night float64
+	// beginning and end of the interval, Standard Julian Dates,
+	// 0 when the body is not visible that night
+	Start, End float64
+}
+
+// Returns true if the body is above the horizon while the Sun is below -12 degrees.
+func isVisible(jd, lng, lat float64, body core.Body) bool {
+	nut := core.ComputeNutation(jd)
+	if _, alt := hourAngleAltitude(jd, lng, lat, core.Sun, nut); alt >= _DARK_ALT {
+		return false
+	}
+	_, alt := hourAngleAltitude(jd, lng, lat, body, nut)
+	return alt > _VISIBLE_ALT
+}
+
+// Finds the moment between a and b when visibility changes, given visibility at a.
+func visibilityEdge(a, b, lng, lat float64, body core.Body, va bool) float64 {
+	for b-a > _TIME_EPS {
+		m := (a + b) / 2
+		if isVisible(m, lng, lat, body) == va {
+			a = m
+		} else {
+			b = m
+		}
+	}
+	return (a + b) / 2
+}
+
+// Intervals when a body is above the horizon while the sky is dark, for successive
+// nights, starting with the night after the local noon of the civil (UT) date of
+// jdStart, Standard Julian Date. lng and lat are geographical longitude (negative
+// westwards) and latitude of the observer, arc-degrees.
+//
+// The body must be above the geometric horizon and the Sun below -12 degrees, which
+// is the end of nautical twilight. Each night is scanned from noon to noon
+// every 5 minutes and edges of the interval are refined by bisection. If the body
+// does not rise at night, or the sky does not get dark, e.g. during white nights, the
+// window has zero Start and End. In the rare case of two intervals during one night
+// (e.g. the Moon sets after dusk and rises again before dawn), the longer one is returned.
+func VisibilityWindows(jdStart float64, days int, lng, lat float64, body core.Body) []Window {
+	res := make([]Window, days)
+	noon := julian.JulianMidnight(jdStart) + 0.5 - lng/360
+	for i := range res {
+		w := Window{Night: noon + float64(i)}
+		prev, vprev := w.Night, isVisible(w.Night, lng, lat, body)
+		start := 0.0
+		if vprev {
+			start = w.Night
+		}
+		for t := w.Night + _NIGHT_STEP; t <= w.Night+1+_NIGHT_STEP/2; t += _NIGHT_STEP {
+			v := isVisible(t, lng, lat, body)
+			switch {
+			case v && !vprev:
+				start = visibilityEdge(prev, t, lng, lat, body, false)
+			case !v && vprev:
+				end := visibilityEdge(prev, t, lng, lat, body, true)
+				if end-start > w.End-w.Start {
+					w.Start, w.End = start, end
+				}
+			}
+			prev, vprev = t, v
+		}
+		if vprev && prev-start > w.End-w.Start {
+			w.Start, w.End = start, prev
+		}
+		res[i] = w
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+package riseset
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/sun"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Greenwich Observatory
+const (
+	_GREENWICH_LNG = 0.0
+	_GREENWICH_LAT = 51.4769
+)
+
+func TestAltitudeRateSun(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 20})
+	for _, lat := range []float64{0, _GREENWICH_LAT} {
+		rise, set, err := sun.RiseSet(jd, _GREENWICH_LNG, lat)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		r := AltitudeRate(rise, _GREENWICH_LNG, lat, core.Sun)
+		s := AltitudeRate(set, _GREENWICH_LNG, lat, core.Sun)
+		if r <= 0 || s >= 0 {
+			t.Errorf("Expected positive rate at rise and negative at set, got: %f, %f", r, s)
+		}
+		// at equinox the Sun moves along the equator: 0.25 * cos(lat) degrees per minute
+		exp := 0.25 * math.Cos(mathutils.Radians(lat))
+		if !mathutils.AlmostEqual(r, exp, 0.005) {
+			t.Errorf("Expected: %f, got: %f", exp, r)
+		}
+	}
+}
+
+func TestAltitudeRateMoon(t *testing.T) {
+	// Moon at Greenwich, 2024 Jan 25: moonrise at about 15:40 UT, moonset on Jan 26 at about 08:40 UT
+	rise := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 25 + 15.67/24})
+	set := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 26 + 8.67/24})
+	if r := AltitudeRate(rise, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon); r <= 0 {
+		t.Errorf("Expected positive rate at rise, got: %f", r)
+	}
+	if s := AltitudeRate(set, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon); s >= 0 {
+		t.Errorf("Expected negative rate at set, got: %f", s)
+	}
+}
+
+func TestAltitudeRateForObserver(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 20.3})
+	obs := core.Observer{Longitude: _GREENWICH_LNG, Latitude: _GREENWICH_LAT}
+	for _, body := range []core.Body{core.Sun, core.Moon} {
+		got := AltitudeRateForObserver(jd, obs, body)
+		exp := AltitudeRate(jd, obs.Longitude, obs.Latitude, body)
+		if got != exp {
+			t.Errorf("%s, expected: %f, got: %f", body, exp, got)
+		}
+	}
+}
+
+func TestNextTransitAbove(t *testing.T) {
+	// winter solstice, 2024 Dec 21
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 12, Day: 21})
+	// at Tromso the Sun culminates about 3 degrees below the horizon
+	got, ok := NextTransitAbove(jd, 18.96, 69.65, core.Sun, 0)
+	if ok {
+		t.Error("Expected the Sun below the horizon at noon")
+	}
+	// local noon is about 12:00 - 18.96 * 4 min., corrected by the equation of time
+	exp := jd + 0.5 - 18.96/360 - sun.EquationOfTime(got)/1440
+	if !mathutils.AlmostEqual(got, exp, 1.0/1440) {
+		t.Errorf("Expected: %s, got: %s", julian.JulianToDateString(exp), julian.JulianToDateString(got))
+	}
+	if alt := altitude(got, 18.96, 69.65, core.Sun); !mathutils.AlmostEqual(alt, -2.8, 0.5) {
+		t.Errorf("Expected altitude: %f, got: %f", -2.8, alt)
+	}
+	// at Greenwich the Sun rises to about 15 degrees
+	if _, ok := NextTransitAbove(jd, _GREENWICH_LNG, _GREENWICH_LAT, core.Sun, 10); !ok {
+		t.Error("Expected the Sun above 10 degrees at noon")
+	}
+	// the Moon crosses the meridian at hour angle 0 once a day, about 50 minutes later each day
+	t1, _ := NextTransitAbove(jd, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon, 0)
+	t2, _ := NextTransitAbove(t1+0.01, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon, 0)
+	if t1 <= jd || t1 > jd+1.1 {
+		t.Errorf("Expected the next transit, got: %s", julian.JulianToDateString(t1))
+	}
+	if d := (t2 - t1 - 1) * 1440; d < 30 || d > 70 {
+		t.Errorf("Expected delay about 50 min., got: %f", d)
+	}
+	if ha, _ := hourAngleAltitude(t1, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon, core.ComputeNutation(t1)); !mathutils.AlmostEqual(ha, 0, 1e-3) {
+		t.Errorf("Expected hour angle: 0, got: %f", ha)
+	}
+}
+
+func TestVisibilityWindows(t *testing.T) {
+	// the Moon near Full Moon of 2024 January 25: visible most of the night
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 20})
+	got := VisibilityWindows(jd, 7, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon)
+	if len(got) != 7 {
+		t.Fatalf("Expected 7 nights, got: %d", len(got))
+	}
+	for i, w := range got {
+		if !mathutils.AlmostEqual(w.Night, jd+0.5+float64(i), 1e-9) {
+			t.Errorf("Expected night: %f, got: %f", jd+0.5+float64(i), w.Night)
+		}
+		if w.Start <= w.Night || w.End >= w.Night+1 || w.End-w.Start < 0.4 {
+			t.Errorf("Unexpected window: %s - %s", julian.JulianToDateString(w.Start), julian.JulianToDateString(w.End))
+			continue
+		}
+		mid := (w.Start + w.End) / 2
+		if altitude(mid, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon) <= 0 {
+			t.Errorf("Expected the Moon above the horizon at %s", julian.JulianToDateString(mid))
+		}
+		if alt := altitude(w.Start, _GREENWICH_LNG, _GREENWICH_LAT, core.Sun); !mathutils.AlmostEqual(alt, -12, 1e-3) {
+			t.Errorf("Expected the window to start at the end of twilight, Sun altitude: %f", alt)
+		}
+	}
+	// the Moon is not visible at night in June 2024, near New Moon
+	jd = julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 5})
+	for _, w := range VisibilityWindows(jd, 2, _GREENWICH_LNG, _GREENWICH_LAT, core.Moon) {
+		if w.Start != 0 || w.End != 0 {
+			t.Errorf("Unexpected window: %s - %s", julian.JulianToDateString(w.Start), julian.JulianToDateString(w.End))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package sun
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Precomputed apparent positions of the Sun within one day, for cheap repeated
+// calculations, e.g. every minute. Create it with [DailyModel].
+type DailySunModel struct {
+	jd     float64    // start of the interval
+	lambda [3]float64 // longitudes at start, middle and end, without 360 jumps
+	delta  [3]float64 // distances at the same moments
+}
+
+// Prepares a model of the Sun's apparent motion during 24 hours starting at
+// jd, Standard Julian Date. Positions of [core.PrecisionHigh] are calculated
+// at the start, middle and end of the interval and interpolated by a parabola,
+// which keeps error of the longitude well below 1 arc-second.
+func DailyModel(jd float64) *DailySunModel {
+	m := &DailySunModel{jd: jd}
+	for i := range m.lambda {
+		pos := Position(jd+float64(i)/2, core.PrecisionHigh)
+		m.lambda[i] = pos.Lambda
+		m.delta[i] = pos.Delta
+		if i > 0 && m.lambda[i] < m.lambda[i-1] {
+			m.lambda[i] += 360
+		}
+	}
+	return m
+}
+
+// Three-point interpolation of values at n = -1, 0, 1 for -1 <= n <= 1.
+func interpolate(y [3]float64, n float64) float64 {
+	a := y[1] - y[0]
+	b := y[2] - y[1]
+	return y[1] + n/2*(a+b+n*(b-a))
+}
+
+// Apparent position of the Sun at a given fraction of the day, 0..1,
+// counted from the model's start. Values outside this range are extrapolated
+// and quickly lose accuracy.
+func (m *DailySunModel) PositionAt(fractionOfDay float64) core.EclipticPosition {
+	n := fractionOfDay*2 - 1
+	return core.EclipticPosition{
+		Lambda: mathutils.ReduceDeg(interpolate(m.lambda, n)),
+		Delta:  interpolate(m.delta, n),
+	}
+}
+
+// Interval between the moments when nutation is calculated exactly by [LongitudeSeries], days.
+const _NUTATION_STEP = 1.0
+
+// Apparent geocentric longitudes of the Sun, arc-degrees, for n moments
+// starting at jdStart, Standard Julian Date, separated by jdStep days,
+// e.g. for animation of the Sun's motion along the zodiac.
+//
+// Results are the same as Lambda of [Position] with [core.PrecisionHigh], reduced to 0..360,
+// except that for steps shorter than a day the nutation, which is the most expensive part,
+// is calculated once a day and interpolated linearly. This adds an error of less than 0.01".
+func LongitudeSeries(jdStart, jdStep float64, n int) []float64 {
+	res := make([]float64, n)
+	node := math.NaN()
+	var dpsi0, dpsi1 float64
+	for i := range res {
+		jd := jdStart + float64(i)*jdStep
+		var dpsi float64
+		if math.Abs(jdStep) < _NUTATION_STEP {
+			x := (jd - jdStart) / _NUTATION_STEP
+			k := math.Floor(x)
+			switch k {
+			case node:
+			case node + 1:
+				dpsi0 = dpsi1
+				dpsi1 = core.ComputeNutation(jdStart + (k+1)*_NUTATION_STEP).Dpsi
+			default:
+				dpsi0 = core.ComputeNutation(jdStart + k*_NUTATION_STEP).Dpsi
+				dpsi1 = core.ComputeNutation(jdStart + (k+1)*_NUTATION_STEP).Dpsi
+			}
+			node = k
+			dpsi = dpsi0 + (dpsi1-dpsi0)*(x-k)
+		} else {
+			dpsi = core.ComputeNutation(jd).Dpsi
+		}
+		t := (jd - julian.J1900) / julian.DAYS_PER_CENT
+		lsn, _ := TrueGeocentric(t, MeanAnomaly(t), MeanLongitude(t))
+		res[i] = mathutils.ReduceDeg(lsn + dpsi - ABERRATION)
+	}
+	return res
+}
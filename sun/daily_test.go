@@ -0,0 +1,79 @@
+package sun
+
+import (
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+	"github.com/skrushinsky/scaliger/nutequ"
+)
+
+func TestDailyModel(t *testing.T) {
+	for _, date := range []julian.CivilDate{
+		{Year: 2024, Month: 1, Day: 3},
+		{Year: 2024, Month: 3, Day: 20}, // longitude passes 0
+		{Year: 1987, Month: 4, Day: 10},
+	} {
+		jd := julian.CivilToJulian(date)
+		m := DailyModel(jd)
+		for minute := 0; minute <= 1440; minute += 10 {
+			f := float64(minute) / 1440
+			exp := Position(jd+f, core.PrecisionHigh)
+			got := m.PositionAt(f)
+			if d := (mathutils.ReduceDeg(got.Lambda-exp.Lambda+180) - 180) * 3600; !mathutils.AlmostEqual(d, 0, 1) {
+				t.Errorf("%v, minute %d, expected: %f, got: %f", date, minute, exp.Lambda, got.Lambda)
+			}
+			if !mathutils.AlmostEqual(got.Delta, exp.Delta, 1e-7) {
+				t.Errorf("%v, minute %d, expected: %f, got: %f", date, minute, exp.Delta, got.Delta)
+			}
+		}
+	}
+}
+
+func BenchmarkDailyModel(b *testing.B) {
+	m := DailyModel(julian.J2000)
+	for i := 0; i < b.N; i++ {
+		m.PositionAt(float64(i%1440) / 1440)
+	}
+}
+
+func BenchmarkDailyRecompute(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Position(julian.J2000+float64(i%1440)/1440, core.PrecisionHigh)
+	}
+}
+
+func TestLongitudeSeries(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 19})
+	for _, step := range []float64{1.0 / 24, -0.3, 10} {
+		got := LongitudeSeries(jd, step, 100)
+		if len(got) != 100 {
+			t.Fatalf("Expected 100 values, got: %d", len(got))
+		}
+		for i, lambda := range got {
+			ti := jd + float64(i)*step
+			dpsi, _ := nutequ.Nutation(ti)
+			exp := Apparent(ti, newOptions(ti, dpsi)).Lambda
+			if d := (mathutils.ReduceDeg(lambda-exp+180) - 180) * 3600; !mathutils.AlmostEqual(d, 0, 0.01) {
+				t.Errorf("Step %f, index %d, expected: %f, got: %f", step, i, exp, lambda)
+			}
+		}
+	}
+}
+
+func BenchmarkLongitudeSeries(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		LongitudeSeries(julian.J2000, 1.0/24, 1000)
+	}
+}
+
+func BenchmarkLongitudeApparent(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			jd := julian.J2000 + float64(j)/24
+			dpsi, _ := nutequ.Nutation(jd)
+			Apparent(jd, newOptions(jd, dpsi))
+		}
+	}
+}
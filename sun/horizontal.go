@@ -0,0 +1,284 @@
+package sun
+
+import (
+	"math"
+
+	"github.com/skrushinsky/kepler/constants"
+	"github.com/skrushinsky/kepler/coord"
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Options for apparent position of the Sun at jd, Standard Julian Date,
+// given dpsi, nutation in longitude. Light-time correction is skipped, since
+// for the Sun it is already accounted for by the aberration constant.
+func newOptions(jd, dpsi float64) ApparentSunOptions {
+	t := (jd - julian.J1900) / julian.DAYS_PER_CENT
+	return ApparentSunOptions{
+		dpsi:              dpsi,
+		ignoreLightTravel: true,
+		meanLongitude:     MeanLongitude(t),
+		meanAnomaly:       MeanAnomaly(t),
+	}
+}
+
+// Options of [Apparent] for jd, Standard Julian Date, given nutation for the same date,
+// see [core.ComputeNutation]. The result of [Apparent] is the apparent longitude
+// of the Sun, referred to the true equinox of date.
+func OptionsWithNutation(jd float64, nut core.Nutation) ApparentSunOptions {
+	return newOptions(jd, nut.Dpsi)
+}
+
+// Same as [OptionsWithNutation], but aberration is omitted, so that [Apparent] returns
+// the "true" longitude of the Sun, referred to the true equinox of date, i.e. the geometric
+// longitude of [TrueGeocentric] corrected for nutation.
+func OptionsWithoutAberration(jd float64, nut core.Nutation) ApparentSunOptions {
+	opts := newOptions(jd, nut.Dpsi)
+	opts.ignoreAberration = true
+	return opts
+}
+
+// Apparent geocentric equatorial position of the Sun for jd, Standard Julian Date,
+// referred to the true equator and equinox of date. Angles in arc-degrees.
+func Equatorial(jd float64) core.EquatorialPosition {
+	return EquatorialWithNutation(jd, core.ComputeNutation(jd))
+}
+
+// Same as [Equatorial], given nutation for jd, see [core.ComputeNutation].
+func EquatorialWithNutation(jd float64, nut core.Nutation) core.EquatorialPosition {
+	pos := Apparent(jd, newOptions(jd, nut.Dpsi))
+	return core.EclipticToEquatorial(pos, nut.TrueObliquity(jd))
+}
+
+// Same as [Equatorial], but with a custom obliquity of the ecliptic, eps, arc-degrees.
+// For instance, J2000 obliquity gives position for the equator of J2000 and
+// equinox of date.
+func EquatorialWithObliquity(jd, eps float64) core.EquatorialPosition {
+	return core.EclipticToEquatorial(Apparent(jd, OptionsWithNutation(jd, core.ComputeNutation(jd))), eps)
+}
+
+// n points of the ecliptic, evenly spaced in longitude from 0, as equatorial coordinates
+// for the true obliquity of jd, Standard Julian Date, e.g. for drawing the ecliptic
+// on a star chart. The points lie on a great circle inclined to the equator by the obliquity.
+func EclipticLine(jd float64, n int) []core.EquatorialPosition {
+	eps := core.TrueObliquity(jd)
+	res := make([]core.EquatorialPosition, n)
+	for i := range res {
+		res[i] = core.EclipticToEquatorial(core.EclipticPosition{Lambda: 360 * float64(i) / float64(n)}, eps)
+	}
+	return res
+}
+
+// Galactic longitude l and latitude b of the Sun, arc-degrees, for jd, Standard Julian Date.
+// Apparent position is precessed to J2000; nutation and aberration, which remain in it,
+// are well below a minute of arc. Through the year the Sun moves along the ecliptic,
+// which is inclined by about 60 degrees to the galactic plane, and passes near the galactic
+// center at the December solstice.
+func Galactic(jd float64) (l, b float64) {
+	return coord.EquatorialToGalactic(coord.Precess(Equatorial(jd), jd, julian.J2000))
+}
+
+// Local hour angle, arc-degrees, in range -180..180, and apparent declination of the Sun
+// for jd, Standard Julian Date, given geographical longitude, lng, negative westwards.
+func hourAngle(jd, lng float64) (ha, delta float64) {
+	nut := core.ComputeNutation(jd)
+	equ := EquatorialWithNutation(jd, nut)
+	ha = mathutils.ReduceDeg(nut.SiderealTime(jd, lng)*15-equ.Alpha+180) - 180
+	return ha, equ.Delta
+}
+
+// Horizontal position of the Sun for jd, Standard Julian Date, given geographical
+// longitude (negative westwards) and latitude of the observer, arc-degrees.
+//
+// Altitude is true (airless), it is not corrected for refraction.
+func AltAz(jd, lng, lat float64) core.HorizontalPosition {
+	ha, delta := hourAngle(jd, lng)
+	return core.EquatorialToHorizontal(ha, delta, lat)
+}
+
+// Horizontal position of the antisolar point, directly opposite the Sun, for jd,
+// Standard Julian Date, given geographical longitude (negative westwards) and latitude
+// of the observer, arc-degrees. Azimuth is measured from the North eastwards.
+//
+// Earth's shadow points there: at sunset the shadow rises in the East, under the pinkish
+// Belt of Venus, and during a lunar eclipse the Moon is close to the antisolar point.
+// The Sun's apparent geocentric direction is reversed, i.e. its right ascension is increased
+// by 180 degrees and declination changes sign, and converted to true (airless) altitude.
+func AntisolarPoint(jd, lng, lat float64) (azimuth, altitude float64) {
+	ha, delta := hourAngle(jd, lng)
+	pos := core.EquatorialToHorizontal(ha+180, -delta, lat)
+	return pos.Azimuth, pos.Altitude
+}
+
+// Same as [AltAz] for an observer. Elevation is ignored.
+func AltAzForObserver(jd float64, obs core.Observer) core.HorizontalPosition {
+	return AltAz(jd, obs.Longitude, obs.Latitude)
+}
+
+// Horizontal positions of the Sun for many observers at jd, Standard Julian Date.
+//
+// Each element of locations is a pair of geographical longitude (negative westwards)
+// and latitude, arc-degrees. elevation is the observers' height above sea level, meters.
+// The result contains pairs of azimuth and altitude, arc-degrees.
+//
+// Unlike [AltAz], altitude is topocentric: it is corrected for the Sun's diurnal
+// parallax, which does not exceed 9 arc-seconds. Refraction is not applied.
+// The Sun's apparent position and sidereal time are computed only once,
+// so this is much faster than calling [AltAz] for each location.
+func AltAzMulti(jd float64, locations [][2]float64, elevation float64) [][2]float64 {
+	nut := core.ComputeNutation(jd)
+	pos := Apparent(jd, newOptions(jd, nut.Dpsi))
+	equ := core.EclipticToEquatorial(pos, nut.TrueObliquity(jd))
+	gst := nut.SiderealTime(jd, 0) * 15
+	rho := 1 + elevation/1000/constants.EARTH_RADIUS            // geocentric distance of the observer, Earth radii
+	sinp := math.Sin(mathutils.Radians(8.794/3600)) / pos.Delta // sine of the Sun's horizontal parallax
+	res := make([][2]float64, 0, len(locations))
+	for _, loc := range locations {
+		hor := core.EquatorialToHorizontal(gst+loc[0]-equ.Alpha, equ.Delta, loc[1])
+		par := mathutils.Degrees(math.Asin(rho * sinp * math.Cos(mathutils.Radians(hor.Altitude))))
+		res = append(res, [2]float64{hor.Azimuth, hor.Altitude - par})
+	}
+	return res
+}
+
+// Apparent altitude of the Sun, arc-degrees, corrected for refraction,
+// at a given local civil time.
+//
+// tzOffset is the time zone offset from UTC in hours, positive eastwards,
+// e.g. 3 for Moscow or -5 for New York Standard Time. Geographical longitude,
+// lng, is negative westwards. Both lng and lat are in arc-degrees.
+func AltitudeAt(year, month, day, hour, minute int, lng, lat, tzOffset float64) float64 {
+	ut := float64(hour) + float64(minute)/60 - tzOffset
+	jd := julian.CivilToJulian(julian.CivilDate{Year: year, Month: month, Day: float64(day) + ut/24})
+	alt := AltAz(jd, lng, lat).Altitude
+	return alt + core.Refraction(alt)
+}
+
+// Length and direction of the shadow cast by a vertical object of height h
+// at jd, Standard Julian Date, given geographical longitude (negative westwards)
+// and latitude of the observer, arc-degrees.
+//
+// Length is expressed in the same units as h. Azimuth of the shadow, arc-degrees,
+// measured from the North eastwards, is opposite to the Sun's azimuth.
+// Apparent altitude of the Sun, corrected for refraction, is used. When the Sun is
+// below the horizon, the length is +Inf.
+func ShadowLength(jd, lng, lat, h float64) (length, azimuth float64) {
+	pos := AltAz(jd, lng, lat)
+	azimuth = mathutils.ReduceDeg(pos.Azimuth + 180)
+	alt := pos.Altitude + core.Refraction(pos.Altitude)
+	if alt <= 0 {
+		return math.Inf(1), azimuth
+	}
+	return h / math.Tan(mathutils.Radians(alt)), azimuth
+}
+
+// Equation of time, minutes, for jd, Standard Julian Date: difference between
+// apparent and mean solar time. Positive value means that a sundial is ahead
+// of the clock.
+//
+// Meeus, "Astronomical Algorithms", 28.3.
+func EquationOfTime(jd float64) float64 {
+	t := (jd - julian.J1900) / julian.DAYS_PER_CENT
+	nut := core.ComputeNutation(jd)
+	equ := EquatorialWithNutation(jd, nut)
+	e := MeanLongitude(t) - ABERRATION - equ.Alpha + nut.Dpsi*math.Cos(mathutils.Radians(nut.TrueObliquity(jd)))
+	return (mathutils.ReduceDeg(e+180) - 180) * 4
+}
+
+// Equation of time, minutes, for jd, Standard Julian Date, split into two components,
+// see [EquationOfTime].
+//
+// The eccentricity component is the difference between mean and true longitudes
+// of the Sun: near perihelion, in January, the Sun moves faster than on average.
+// It is negative from January to July and positive from July to January, up to 7.7 minutes.
+// The obliquity component is the difference between the Sun's longitude and its right
+// ascension, i.e. the projection of the ecliptic onto the equator. It vanishes at
+// equinoxes and solstices, is positive when the Sun moves from an equinox to a solstice
+// and negative otherwise, up to 9.9 minutes. Together they shape the analemma.
+func EquationOfTimeComponents(jd float64) (eccentricity, obliquity, total float64) {
+	t := (jd - julian.J1900) / julian.DAYS_PER_CENT
+	nut := core.ComputeNutation(jd)
+	eps := nut.TrueObliquity(jd)
+	pos := Apparent(jd, newOptions(jd, nut.Dpsi))
+	equ := core.EclipticToEquatorial(pos, eps)
+	reduce := func(x float64) float64 { return (mathutils.ReduceDeg(x+180) - 180) * 4 }
+	eccentricity = reduce(MeanLongitude(t) - ABERRATION + nut.Dpsi - pos.Lambda)
+	obliquity = reduce(pos.Lambda - equ.Alpha + nut.Dpsi*(math.Cos(mathutils.Radians(eps))-1))
+	return eccentricity, obliquity, eccentricity + obliquity
+}
+
+// Equation of time on a day of a year, see [EquationOfTimeTable].
+type EquationOfTimeDay struct {
+	// day of the year, 1 for January 1
+	DOY int
+	// equation of time, minutes
+	Minutes float64
+}
+
+// Equation of time at Greenwich noon of each day of a civil year, see [EquationOfTime],
+// e.g. for a table of sundial corrections. The curve has two unequal maxima, in May
+// (about +3.7 minutes) and early November (+16.4), and two minima, in February (-14.2)
+// and late July (-6.5); it crosses zero in mid-April, mid-June, early September
+// and late December.
+func EquationOfTimeTable(year int) []EquationOfTimeDay {
+	start := julian.CivilToJulian(julian.CivilDate{Year: year, Month: 1, Day: 1})
+	end := julian.CivilToJulian(julian.CivilDate{Year: year + 1, Month: 1, Day: 1})
+	res := make([]EquationOfTimeDay, int(end-start))
+	for i := range res {
+		res[i] = EquationOfTimeDay{DOY: i + 1, Minutes: EquationOfTime(start + float64(i) + 0.5)}
+	}
+	return res
+}
+
+// Local apparent (sundial) time, decimal hours, for jd, Standard Julian Date,
+// given geographical longitude, lng, arc-degrees, negative westwards.
+//
+// It is the local hour angle of the Sun plus 12 hours, so that the apparent noon
+// occurs at the Sun's upper transit.
+func LocalApparentTime(jd, lng float64) float64 {
+	ha, _ := hourAngle(jd, lng)
+	return mathutils.ReduceHours(ha/15 + 12)
+}
+
+// Local mean time, decimal hours, for jd, Standard Julian Date,
+// given geographical longitude, lng, arc-degrees, negative westwards.
+//
+// It is the Universal Time shifted by 4 minutes per degree of longitude.
+// Civil (zone) time differs from it by the offset between the observer's meridian
+// and the time zone meridian, plus daylight saving shift, if any.
+// Local apparent time minus local mean time is the [EquationOfTime].
+func LocalMeanTime(jd, lng float64) float64 {
+	return mathutils.ReduceHours(julian.ExtractUTC(jd) + lng/15)
+}
+
+// Yearly envelope of the Sun's positions on the sky for latitude lat, arc-degrees,
+// useful for designing windows and shading.
+//
+// minAz and maxAz are azimuths of sunrise and sunset at the local summer solstice,
+// the northernmost (or, in the Southern hemisphere, southernmost) points of the horizon
+// where the Sun appears. North of the tropics the Sun sweeps clockwise from minAz
+// through the South to maxAz, south of the tropics anticlockwise through the North.
+// Beyond the polar circles the summer Sun does not set and the range is 0..360.
+//
+// maxAlt and minAlt are altitudes of the Sun at noon of the summer and winter solstices.
+// maxAlt is 90 degrees within the tropics, minAlt is negative within the polar circles.
+//
+// Declination of the Sun at solstices is taken equal to the mean obliquity of J2000.
+// Geometric horizon is used, refraction is ignored.
+func SolarWindow(lat float64) (minAz, maxAz, maxAlt, minAlt float64) {
+	eps, _ := core.MeanObliquity(julian.J2000)
+	phi := math.Abs(lat)
+	maxAlt = math.Min(90, 90-phi+eps)
+	minAlt = 90 - phi - eps
+	delta := eps
+	if lat < 0 {
+		delta = -eps
+	}
+	cosa := math.Sin(mathutils.Radians(delta)) / math.Cos(mathutils.Radians(lat))
+	if cosa >= 1 || cosa <= -1 {
+		return 0, 360, maxAlt, minAlt
+	}
+	minAz = mathutils.Degrees(math.Acos(cosa))
+	return minAz, 360 - minAz, maxAlt, minAlt
+}
@@ -0,0 +1,362 @@
+package sun
+
+import (
+	"math"
+	"testing"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+func TestEquatorial(t *testing.T) {
+	// Meeus, example 25.a, 1992 Oct. 13, 0h TD. The theory differs from Meeus by ~10"
+	got := Equatorial(2448908.5)
+	if !mathutils.AlmostEqual(got.Alpha, 198.38083, 5e-3) {
+		t.Errorf("Expected Alpha: %f, got: %f", 198.38083, got.Alpha)
+	}
+	if !mathutils.AlmostEqual(got.Delta, -7.78507, 2e-3) {
+		t.Errorf("Expected Delta: %f, got: %f", -7.78507, got.Delta)
+	}
+}
+
+func TestEquatorialWithObliquity(t *testing.T) {
+	// in 2000 the equinox of date is close to that of J2000, so that with the J2000 mean
+	// obliquity, 23.4392911, the Sun's coordinates at equinoxes and solstices published
+	// by the Astronomical Almanac are 0h, 0 and 6h, +eps. Delta T is 64 seconds.
+	eps, _ := core.MeanObliquity(julian.J2000)
+	const dt = 64.0 / 86400
+	for _, c := range []struct {
+		date         julian.CivilDate
+		alpha, delta float64
+	}{
+		// March equinox, 2000 March 20, 07:35 UT
+		{julian.CivilDate{Year: 2000, Month: 3, Day: 20 + (7+35.0/60)/24}, 0, 0},
+		// June solstice, 2000 June 21, 01:48 UT
+		{julian.CivilDate{Year: 2000, Month: 6, Day: 21 + (1+48.0/60)/24}, 90, eps},
+	} {
+		got := EquatorialWithObliquity(julian.CivilToJulian(c.date)+dt, eps)
+		if d := math.Abs(mathutils.ReduceDeg(got.Alpha-c.alpha+180) - 180); d > 5e-3 {
+			t.Errorf("Expected Alpha: %f, got: %f", c.alpha, got.Alpha)
+		}
+		if !mathutils.AlmostEqual(got.Delta, c.delta, 1e-3) {
+			t.Errorf("Expected Delta: %f, got: %f", c.delta, got.Delta)
+		}
+	}
+}
+
+func TestAltAz(t *testing.T) {
+	// 2024 June 21, 12:00 UT, Greenwich, the Sun is about to culminate in the South
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21.5})
+	got := AltAz(jd, 0, 51.4769)
+	if !mathutils.AlmostEqual(got.Altitude, 61.96, 0.05) {
+		t.Errorf("Expected Altitude: %f, got: %f", 61.96, got.Altitude)
+	}
+	if !mathutils.AlmostEqual(got.Azimuth, 180, 1) {
+		t.Errorf("Expected Azimuth: %f, got: %f", 180.0, got.Azimuth)
+	}
+}
+
+var locations = [][2]float64{
+	{0, 51.4769},         // Greenwich
+	{37.6173, 55.7558},   // Moscow
+	{-73.9654, 40.7829},  // New York
+	{151.2153, -33.8568}, // Sydney
+	{18.96, 69.65},       // Tromso
+}
+
+func TestAltAzMulti(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21.4})
+	got := AltAzMulti(jd, locations, 0)
+	if len(got) != len(locations) {
+		t.Fatalf("Expected %d positions, got: %d", len(locations), len(got))
+	}
+	for i, loc := range locations {
+		exp := AltAz(jd, loc[0], loc[1])
+		if !mathutils.AlmostEqual(got[i][0], exp.Azimuth, 1e-6) {
+			t.Errorf("Expected Azimuth: %f, got: %f", exp.Azimuth, got[i][0])
+		}
+		// difference is the Sun's parallax in altitude
+		if d := exp.Altitude - got[i][1]; d < 0 || d > 9.0/3600 {
+			t.Errorf("Expected Altitude: %f, got: %f", exp.Altitude, got[i][1])
+		}
+	}
+}
+
+func makeLocations(n int) [][2]float64 {
+	res := make([][2]float64, 0, n)
+	for i := 0; i < n; i++ {
+		res = append(res, locations[i%len(locations)])
+	}
+	return res
+}
+
+func BenchmarkAltAzMulti(b *testing.B) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21.4})
+	locs := makeLocations(100)
+	for i := 0; i < b.N; i++ {
+		AltAzMulti(jd, locs, 0)
+	}
+}
+
+func BenchmarkAltAzSingle(b *testing.B) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21.4})
+	locs := makeLocations(100)
+	for i := 0; i < b.N; i++ {
+		for _, loc := range locs {
+			AltAz(jd, loc[0], loc[1])
+		}
+	}
+}
+
+func TestAltitudeAt(t *testing.T) {
+	// local noon in Moscow (UTC+3) is 09:00 UT
+	lng, lat := 37.6173, 55.7558
+	got := AltitudeAt(2024, 6, 21, 12, 0, lng, lat, 3)
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21 + 9.0/24})
+	exp := AltAz(jd, lng, lat).Altitude
+	if !mathutils.AlmostEqual(got, exp, 0.02) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+	if got <= exp {
+		t.Errorf("Refraction should raise the Sun: %f <= %f", got, exp)
+	}
+	// crossing the date line: 01:00 local at UTC+3 is 22:00 UT of the previous day
+	got = AltitudeAt(2024, 6, 22, 1, 0, lng, lat, 3)
+	jd = julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21 + 22.0/24})
+	exp = AltAz(jd, lng, lat).Altitude
+	if !mathutils.AlmostEqual(got, exp, 1e-6) {
+		t.Errorf("Expected: %f, got: %f", exp, got)
+	}
+}
+
+func TestShadowLength(t *testing.T) {
+	// solar noon on the day of March equinox, 2024 March 20, 12:07 UT.
+	// The Sun is on the equator, so its altitude is 90 - latitude.
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 20 + (12+7.0/60)/24})
+	length, azimuth := ShadowLength(jd, 0, 45, 1)
+	if !mathutils.AlmostEqual(length, 1, 0.01) {
+		t.Errorf("Expected length: 1, got: %f", length)
+	}
+	if az := mathutils.ReduceDeg(azimuth+180) - 180; !mathutils.AlmostEqual(az, 0, 0.5) {
+		t.Errorf("Expected azimuth: 0, got: %f", azimuth)
+	}
+	// midnight
+	length, _ = ShadowLength(jd-0.5, 0, 45, 1)
+	if !math.IsInf(length, 1) {
+		t.Errorf("Expected +Inf, got: %f", length)
+	}
+}
+
+func TestEquationOfTime(t *testing.T) {
+	type _EotCase struct {
+		date julian.CivilDate
+		eot  float64
+	}
+	eotCases := [...]_EotCase{
+		{date: julian.CivilDate{Year: 1992, Month: 10, Day: 13}, eot: 13.70}, // Meeus, example 28.b: 13m42.6s
+		{date: julian.CivilDate{Year: 2024, Month: 2, Day: 11}, eot: -14.2},
+		{date: julian.CivilDate{Year: 2024, Month: 11, Day: 3}, eot: 16.4},
+	}
+	for _, test := range eotCases {
+		got := EquationOfTime(julian.CivilToJulian(test.date))
+		if !mathutils.AlmostEqual(got, test.eot, 0.1) {
+			t.Errorf("Expected: %f, got: %f", test.eot, got)
+		}
+	}
+}
+
+func TestEquationOfTimeComponents(t *testing.T) {
+	cases := [...]struct {
+		date     julian.CivilDate
+		ecc, obl int
+	}{
+		{date: julian.CivilDate{Year: 2024, Month: 2, Day: 11}, ecc: -1, obl: -1},
+		{date: julian.CivilDate{Year: 2024, Month: 5, Day: 5}, ecc: -1, obl: 1},
+		{date: julian.CivilDate{Year: 2024, Month: 8, Day: 15}, ecc: 1, obl: -1},
+		{date: julian.CivilDate{Year: 2024, Month: 11, Day: 3}, ecc: 1, obl: 1},
+	}
+	for _, c := range cases {
+		jd := julian.CivilToJulian(c.date)
+		ecc, obl, total := EquationOfTimeComponents(jd)
+		if !mathutils.AlmostEqual(ecc+obl, total, 1e-12) {
+			t.Errorf("%v, expected sum: %f, got: %f", c.date, total, ecc+obl)
+		}
+		if exp := EquationOfTime(jd); !mathutils.AlmostEqual(total, exp, 1e-9) {
+			t.Errorf("%v, expected: %f, got: %f", c.date, exp, total)
+		}
+		if ecc*float64(c.ecc) <= 0 || obl*float64(c.obl) <= 0 {
+			t.Errorf("%v, unexpected signs of the components: %f, %f", c.date, ecc, obl)
+		}
+		if math.Abs(ecc) > 8 || math.Abs(obl) > 10 {
+			t.Errorf("%v, components are too large: %f, %f", c.date, ecc, obl)
+		}
+	}
+}
+
+func TestLocalApparentTime(t *testing.T) {
+	for _, lng := range []float64{-73.97, 0, 37.62} {
+		for _, day := range []float64{11, 120.25, 310.75} {
+			jd := julian.JulianDateZero(2024) + day
+			lat := LocalApparentTime(jd, lng)
+			lmt := LocalMeanTime(jd, lng)
+			exp := EquationOfTime(jd)
+			got := (mathutils.ReduceHours(lat-lmt+12) - 12) * 60
+			if !mathutils.AlmostEqual(got, exp, 0.05) {
+				t.Errorf("Expected: %f, got: %f", exp, got)
+			}
+		}
+	}
+}
+
+func TestSolarWindow(t *testing.T) {
+	const eps = 23.439291
+	minAz, maxAz, maxAlt, minAlt := SolarWindow(51.4769)
+	if !mathutils.AlmostEqual(maxAlt, 90-51.4769+eps, 1e-4) {
+		t.Errorf("Expected maxAlt: %f, got: %f", 90-51.4769+eps, maxAlt)
+	}
+	if !mathutils.AlmostEqual(minAlt, 90-51.4769-eps, 1e-4) {
+		t.Errorf("Expected minAlt: %f, got: %f", 90-51.4769-eps, minAlt)
+	}
+	// Greenwich: sunrise in the North-East at summer solstice
+	if !mathutils.AlmostEqual(minAz, 50.3, 0.1) || !mathutils.AlmostEqual(maxAz, 360-minAz, 1e-9) {
+		t.Errorf("Expected azimuths: %f and %f, got: %f and %f", 50.3, 309.7, minAz, maxAz)
+	}
+	// Southern hemisphere: sunrise in the South-East at December solstice
+	minAz, maxAz, _, _ = SolarWindow(-51.4769)
+	if !mathutils.AlmostEqual(minAz, 129.7, 0.1) || !mathutils.AlmostEqual(maxAz, 230.3, 0.1) {
+		t.Errorf("Expected azimuths: %f and %f, got: %f and %f", 129.7, 230.3, minAz, maxAz)
+	}
+	// tropics and polar circle
+	if _, _, maxAlt, _ := SolarWindow(10); maxAlt != 90 {
+		t.Errorf("Expected maxAlt: 90, got: %f", maxAlt)
+	}
+	if minAz, maxAz, _, minAlt := SolarWindow(70); minAz != 0 || maxAz != 360 || minAlt >= 0 {
+		t.Errorf("Unexpected window beyond polar circle: %f, %f, %f", minAz, maxAz, minAlt)
+	}
+}
+
+func TestAntisolarPoint(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21})
+	for _, hour := range []float64{0, 4, 9, 15, 20.3} {
+		az, alt := AntisolarPoint(jd+hour/24, _GREENWICH_LNG, _GREENWICH_LAT)
+		sun := AltAz(jd+hour/24, _GREENWICH_LNG, _GREENWICH_LAT)
+		if !mathutils.AlmostEqual(alt, -sun.Altitude, 1e-9) {
+			t.Errorf("Expected altitude: %f, got: %f", -sun.Altitude, alt)
+		}
+		if exp := mathutils.ReduceDeg(sun.Azimuth + 180); !mathutils.AlmostEqual(az, exp, 1e-9) {
+			t.Errorf("Expected azimuth: %f, got: %f", exp, az)
+		}
+	}
+}
+
+func TestForObserver(t *testing.T) {
+	obs := core.Observer{Longitude: _GREENWICH_LNG, Latitude: _GREENWICH_LAT, Elevation: 47}
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21.4})
+	if got, exp := AltAzForObserver(jd, obs), AltAz(jd, obs.Longitude, obs.Latitude); got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+	rise1, set1, err1 := RiseSetForObserver(jd, obs)
+	rise2, set2, err2 := RiseSet(jd, obs.Longitude, obs.Latitude)
+	if rise1 != rise2 || set1 != set2 || err1 != err2 {
+		t.Errorf("Expected: %f, %f, got: %f, %f", rise2, set2, rise1, set1)
+	}
+}
+
+func TestGalactic(t *testing.T) {
+	// December solstice: the Sun is about 6 degrees from the galactic center
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 12, Day: 21})
+	l, b := Galactic(jd)
+	if l > 10 && l < 350 {
+		t.Errorf("Expected l near 0, got: %f", l)
+	}
+	if !mathutils.AlmostEqual(b, 0, 2) {
+		t.Errorf("Expected b near 0, got: %f", b)
+	}
+	// June solstice: toward the galactic anticenter
+	jd = julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21})
+	if l, b := Galactic(jd); !mathutils.AlmostEqual(l, 180, 10) || !mathutils.AlmostEqual(b, 0, 2) {
+		t.Errorf("Expected l near 180 and b near 0, got: %f, %f", l, b)
+	}
+}
+
+func TestEquatorialWithNutation(t *testing.T) {
+	jd := 2448908.5
+	nut := core.ComputeNutation(jd)
+	if got, exp := EquatorialWithNutation(jd, nut), Equatorial(jd); got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+	if got, exp := Apparent(jd, OptionsWithNutation(jd, nut)), Position(jd, core.PrecisionHigh); got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+}
+
+func TestOptionsWithoutAberration(t *testing.T) {
+	jd := 2448908.5
+	nut := core.ComputeNutation(jd)
+	lsn, rsn := geocentric(jd)
+	got := Apparent(jd, OptionsWithoutAberration(jd, nut))
+	if exp := lsn + nut.Dpsi; !mathutils.AlmostEqual(got.Lambda, exp, 1e-12) {
+		t.Errorf("Expected: %f, got: %f", exp, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Delta, rsn, 1e-12) {
+		t.Errorf("Expected: %f, got: %f", rsn, got.Delta)
+	}
+	if exp := Apparent(jd, OptionsWithNutation(jd, nut)).Lambda + ABERRATION; !mathutils.AlmostEqual(got.Lambda, exp, 1e-12) {
+		t.Errorf("Expected: %f, got: %f", exp, got.Lambda)
+	}
+}
+
+func TestEclipticLine(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 1})
+	eps := core.TrueObliquity(jd)
+	got := EclipticLine(jd, 36)
+	if len(got) != 36 {
+		t.Fatalf("Expected 36 points, got: %d", len(got))
+	}
+	maxDelta := 0.0
+	for i, p := range got {
+		// the point is on the great circle with the pole at the pole of the ecliptic
+		ecl := core.EquatorialToEcliptic(p, eps)
+		if !mathutils.AlmostEqual(ecl.Beta, 0, 1e-9) {
+			t.Errorf("Expected Beta: 0, got: %f", ecl.Beta)
+		}
+		if lon := float64(i) * 10; !mathutils.AlmostEqual(ecl.Lambda, lon, 1e-9) && !mathutils.AlmostEqual(ecl.Lambda, lon+360, 1e-9) {
+			t.Errorf("Expected Lambda: %f, got: %f", lon, ecl.Lambda)
+		}
+		maxDelta = math.Max(maxDelta, math.Abs(p.Delta))
+	}
+	// at the solstices declination equals the obliquity
+	if !mathutils.AlmostEqual(maxDelta, eps, 1e-9) {
+		t.Errorf("Expected: %f, got: %f", eps, maxDelta)
+	}
+	if got[0].Alpha != 0 || got[0].Delta != 0 {
+		t.Errorf("Expected vernal equinox, got: %v", got[0])
+	}
+}
+
+func TestEquationOfTimeTable(t *testing.T) {
+	got := EquationOfTimeTable(2024)
+	if len(got) != 366 {
+		t.Fatalf("Expected 366 days, got: %d", len(got))
+	}
+	if got[0].DOY != 1 || got[365].DOY != 366 {
+		t.Errorf("Unexpected days of the year: %d, %d", got[0].DOY, got[365].DOY)
+	}
+	// zero crossings: April 15, June 13, September 1, December 25
+	exp := []int{106, 165, 245, 360}
+	crossings := make([]int, 0, 4)
+	for i := 1; i < len(got); i++ {
+		if (got[i-1].Minutes < 0) != (got[i].Minutes < 0) {
+			crossings = append(crossings, got[i].DOY)
+		}
+	}
+	if len(crossings) != len(exp) {
+		t.Fatalf("Expected %d zero crossings, got: %v", len(exp), crossings)
+	}
+	for i := range exp {
+		if d := crossings[i] - exp[i]; d < -3 || d > 3 {
+			t.Errorf("Expected crossing near day %d, got: %d", exp[i], crossings[i])
+		}
+	}
+}
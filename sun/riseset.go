@@ -0,0 +1,233 @@
+package sun
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Returned when the Sun stays above the requested altitude all day long.
+var ErrAlwaysAbove = errors.New("the Sun is always above the altitude")
+
+// Returned when the Sun stays below the requested altitude all day long.
+var ErrAlwaysBelow = errors.New("the Sun never reaches the altitude")
+
+// Altitudes of the Sun's center, arc-degrees, which define rise, set and twilight.
+const (
+	// sunrise and sunset: 34' of refraction plus 16' of the Sun's semidiameter
+	HorizonStandard = -0.8333
+	// geometric horizon corrected for 34' of refraction only, used for point-like objects
+	HorizonGeometric = -0.5667
+	// beginning and end of civil twilight
+	TwilightCivil = -6.0
+	// beginning and end of nautical twilight
+	TwilightNautical = -12.0
+	// beginning and end of astronomical twilight
+	TwilightAstronomical = -18.0
+)
+
+// Sidereal rotation rate of the Earth, arc-degrees per solar day
+const _SIDEREAL_RATE = 360.98564736629
+
+// Maximal number of iterations and desired precision (about 0.1 sec.) of time search.
+const (
+	_MAX_ITER = 10
+	_TIME_EPS = 1e-6
+)
+
+// Finds when the Sun reaches a given altitude during the day.
+//
+// jd is a Standard Julian Date, the event is searched around the local noon of its
+// civil (UT) date. lng and lat are geographical longitude (negative westwards) and
+// latitude of the observer. alt is the true (airless) altitude of the Sun's center.
+// If morning is true, the time of the Sun rising to the altitude is returned,
+// otherwise the time of the Sun sinking to it.
+//
+// All angles in arc-degrees. If the Sun does not cross the altitude that day,
+// [ErrAlwaysAbove] or [ErrAlwaysBelow] is returned.
+func TimeAtAltitude(jd, lng, lat, alt float64, morning bool) (float64, error) {
+	sinh := math.Sin(mathutils.Radians(alt))
+	sinp, cosp := math.Sincos(mathutils.Radians(lat))
+	t := julian.JulianMidnight(jd) + 0.5 - lng/360 // approximate local noon
+	for i := 0; i < _MAX_ITER; i++ {
+		ha, delta := hourAngle(t, lng)
+		sind, cosd := math.Sincos(mathutils.Radians(delta))
+		cosh0 := (sinh - sinp*sind) / (cosp * cosd)
+		if cosh0 < -1 {
+			return 0, ErrAlwaysAbove
+		}
+		if cosh0 > 1 {
+			return 0, ErrAlwaysBelow
+		}
+		h0 := mathutils.Degrees(math.Acos(cosh0))
+		if morning {
+			h0 = -h0
+		}
+		dt := (mathutils.ReduceDeg(h0-ha+180) - 180) / _SIDEREAL_RATE
+		t += dt
+		if math.Abs(dt) < _TIME_EPS {
+			break
+		}
+	}
+	return t, nil
+}
+
+// Sunrise and sunset for the civil (UT) date of jd, Standard Julian Date, given
+// geographical longitude (negative westwards) and latitude of the observer, arc-degrees.
+//
+// Upper limb of the Sun touching the horizon, corrected for refraction, is
+// considered, see [HorizonStandard]. During polar day or polar night [ErrAlwaysAbove]
+// or [ErrAlwaysBelow] is returned.
+func RiseSet(jd, lng, lat float64) (rise, set float64, err error) {
+	return Twilight(jd, lng, lat, HorizonStandard)
+}
+
+// Same as [RiseSet] for an observer. Elevation is ignored.
+func RiseSetForObserver(jd float64, obs core.Observer) (rise, set float64, err error) {
+	return RiseSet(jd, obs.Longitude, obs.Latitude)
+}
+
+// Morning and evening twilight for the civil (UT) date of jd, Standard Julian Date, given
+// geographical longitude (negative westwards) and latitude of the observer, arc-degrees.
+// alt is altitude of the Sun's center, usually one of [TwilightCivil],
+// [TwilightNautical] or [TwilightAstronomical].
+//
+// dawn is the beginning of the morning twilight and dusk is the end of the evening twilight.
+// When the Sun does not reach the altitude, [ErrAlwaysAbove] or [ErrAlwaysBelow] is returned.
+func Twilight(jd, lng, lat, alt float64) (dawn, dusk float64, err error) {
+	dawn, err = TimeAtAltitude(jd, lng, lat, alt, true)
+	if err != nil {
+		return
+	}
+	dusk, err = TimeAtAltitude(jd, lng, lat, alt, false)
+	return
+}
+
+// Kind of twilight, defined by a range of the Sun's altitude.
+type TwilightKind int
+
+const (
+	// from sunrise or sunset to the Sun's altitude of -6 degrees
+	CivilTwilight TwilightKind = iota
+	// the Sun between -6 and -12 degrees
+	NauticalTwilight
+	// the Sun between -12 and -18 degrees
+	AstronomicalTwilight
+	// "blue hour" of photographers, the Sun between -4 and -6 degrees
+	BlueHour
+)
+
+// Upper altitude of the Sun's center during the blue hour, arc-degrees.
+const _BLUE_HOUR_ALT = -4.0
+
+// Upper and lower altitudes of the Sun, arc-degrees, limiting the twilight.
+func (k TwilightKind) limits() (upper, lower float64) {
+	switch k {
+	case NauticalTwilight:
+		return TwilightCivil, TwilightNautical
+	case AstronomicalTwilight:
+		return TwilightNautical, TwilightAstronomical
+	case BlueHour:
+		return _BLUE_HOUR_ALT, TwilightCivil
+	default:
+		return HorizonStandard, TwilightCivil
+	}
+}
+
+// Durations of the morning and evening twilight of a given kind, minutes, for the civil
+// (UT) date of jd, Standard Julian Date, given geographical longitude (negative westwards)
+// and latitude of the observer, arc-degrees.
+//
+// In summer at high latitudes the Sun does not sink to the lower limit of the twilight,
+// so that the evening twilight never ends and merges with the morning one. In this case
+// [ErrAlwaysAbove] is returned. If the Sun does not rise to the upper limit,
+// [ErrAlwaysBelow] is returned.
+func TwilightDuration(jd, lng, lat float64, kind TwilightKind) (morning, evening float64, err error) {
+	upper, lower := kind.limits()
+	dawn, dusk, err := Twilight(jd, lng, lat, lower)
+	if err != nil {
+		return
+	}
+	start, end, err := Twilight(jd, lng, lat, upper)
+	if err != nil {
+		return
+	}
+	return (start - dawn) * 1440, (dusk - end) * 1440, nil
+}
+
+// Kind of a day regarding sunrise and sunset.
+type DayStatus int
+
+const (
+	// the Sun rises and sets
+	NormalDay DayStatus = iota
+	// the Sun stays above the horizon
+	PolarDay
+	// the Sun stays below the horizon
+	PolarNight
+)
+
+// Sunrise and sunset for a single day.
+type RiseSetEvent struct {
+	// Standard Julian Date of the Greenwich midnight
+	Date float64
+	// sunrise, Standard Julian Date, 0 unless Status is NormalDay
+	Rise float64
+	// sunset, Standard Julian Date, 0 unless Status is NormalDay
+	Set float64
+	// whether the Sun rises and sets
+	Status DayStatus
+}
+
+// Sunrise and sunset for a number of successive days, starting from the civil (UT) date
+// of jdStart, Standard Julian Date, given geographical longitude (negative westwards)
+// and latitude of the observer, arc-degrees.
+//
+// Polar days and nights do not interrupt the sequence, they are marked by Status field.
+func RiseSetRange(jdStart float64, days int, lng, lat float64) []RiseSetEvent {
+	res := make([]RiseSetEvent, 0, days)
+	start := julian.JulianMidnight(jdStart)
+	for i := 0; i < days; i++ {
+		ev := RiseSetEvent{Date: start + float64(i)}
+		rise, set, err := RiseSet(ev.Date, lng, lat)
+		switch err {
+		case nil:
+			ev.Rise, ev.Set = rise, set
+		case ErrAlwaysAbove:
+			ev.Status = PolarDay
+		case ErrAlwaysBelow:
+			ev.Status = PolarNight
+		}
+		res = append(res, ev)
+	}
+	return res
+}
+
+// Julian Date of the Unix epoch, 1970 Jan 1, 0h UT
+const _UNIX_EPOCH = 2440587.5
+
+// Converts jd, Standard Julian Date, to time in a given location, rounded to a second.
+func julianToTime(jd float64, loc *time.Location) time.Time {
+	sec := math.Round((jd - _UNIX_EPOCH) * julian.SEC_PER_DAY)
+	return time.Unix(int64(sec), 0).In(loc)
+}
+
+// Sunrise and sunset as civil time in loc location, for the calendar date of date
+// in that location, given geographical longitude (negative westwards) and latitude
+// of the observer, arc-degrees.
+//
+// Daylight saving time is handled by loc, e.g. in Europe/London the sunrise jumps
+// by about an hour on the last Sunday of March. See [RiseSet] for errors.
+func RiseSetLocal(date time.Time, lng, lat float64, loc *time.Location) (rise, set time.Time, err error) {
+	y, m, d := date.In(loc).Date()
+	jd := julian.CivilToJulian(julian.CivilDate{Year: y, Month: int(m), Day: float64(d)})
+	r, s, err := RiseSet(jd, lng, lat)
+	if err != nil {
+		return
+	}
+	return julianToTime(r, loc), julianToTime(s, loc), nil
+}
@@ -0,0 +1,185 @@
+package sun
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/skrushinsky/scaliger/julian"
+	"github.com/skrushinsky/scaliger/mathutils"
+)
+
+// Greenwich Observatory
+const (
+	_GREENWICH_LNG = 0.0
+	_GREENWICH_LAT = 51.4769
+)
+
+func TestTimeAtAltitude(t *testing.T) {
+	// golden hour, 2024 June 21
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21})
+	noon := jd + 0.5
+	for _, morning := range []bool{true, false} {
+		got, err := TimeAtAltitude(jd, _GREENWICH_LNG, _GREENWICH_LAT, 6, morning)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		alt := AltAz(got, _GREENWICH_LNG, _GREENWICH_LAT).Altitude
+		if !mathutils.AlmostEqual(alt, 6, 1e-4) {
+			t.Errorf("Expected altitude: 6, got: %f", alt)
+		}
+		if morning && (got > noon || got < jd) {
+			t.Errorf("Expected morning event, got: %s", julian.JulianToDateString(got))
+		}
+		if !morning && (got < noon || got > jd+1) {
+			t.Errorf("Expected evening event, got: %s", julian.JulianToDateString(got))
+		}
+	}
+}
+
+func TestTimeAtAltitudeNeverReached(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21})
+	// maximal altitude of the Sun in Greenwich is about 62 degrees
+	if _, err := TimeAtAltitude(jd, _GREENWICH_LNG, _GREENWICH_LAT, 70, true); err != ErrAlwaysBelow {
+		t.Errorf("Expected ErrAlwaysBelow, got: %v", err)
+	}
+	// polar day at Murmansk
+	if _, err := TimeAtAltitude(jd, 33.08, 68.97, 0, false); err != ErrAlwaysAbove {
+		t.Errorf("Expected ErrAlwaysAbove, got: %v", err)
+	}
+}
+
+func TestRiseSet(t *testing.T) {
+	// Greenwich, 2024 June 21: sunrise at 03:43 UT, sunset at 20:21 UT
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21})
+	rise, set, err := RiseSet(jd, _GREENWICH_LNG, _GREENWICH_LAT)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expRise := jd + (3+43.0/60)/24
+	expSet := jd + (20+21.0/60)/24
+	if !mathutils.AlmostEqual(rise, expRise, 1.5/1440) {
+		t.Errorf("Expected rise: %s, got: %s", julian.JulianToDateString(expRise), julian.JulianToDateString(rise))
+	}
+	if !mathutils.AlmostEqual(set, expSet, 1.5/1440) {
+		t.Errorf("Expected set: %s, got: %s", julian.JulianToDateString(expSet), julian.JulianToDateString(set))
+	}
+	alt := AltAz(rise, _GREENWICH_LNG, _GREENWICH_LAT).Altitude
+	if !mathutils.AlmostEqual(alt, HorizonStandard, 1e-4) {
+		t.Errorf("Expected altitude: %f, got: %f", HorizonStandard, alt)
+	}
+}
+
+func TestTwilight(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 20})
+	rise, set, _ := RiseSet(jd, _GREENWICH_LNG, _GREENWICH_LAT)
+	prevDawn, prevDusk := rise, set
+	for _, alt := range []float64{TwilightCivil, TwilightNautical, TwilightAstronomical} {
+		dawn, dusk, err := Twilight(jd, _GREENWICH_LNG, _GREENWICH_LAT, alt)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if dawn >= prevDawn || dusk <= prevDusk {
+			t.Errorf("Expected deeper twilight to start earlier and end later for altitude %f", alt)
+		}
+		prevDawn, prevDusk = dawn, dusk
+	}
+	// no astronomical night in Greenwich at summer solstice
+	jd = julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21})
+	if _, _, err := Twilight(jd, _GREENWICH_LNG, _GREENWICH_LAT, TwilightAstronomical); err != ErrAlwaysAbove {
+		t.Errorf("Expected ErrAlwaysAbove, got: %v", err)
+	}
+}
+
+func TestTwilightDuration(t *testing.T) {
+	// civil twilight lasts longer at higher latitudes
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 20})
+	prev := 0.0
+	for _, lat := range []float64{0, 20, 40, 60} {
+		morning, evening, err := TwilightDuration(jd, _GREENWICH_LNG, lat, CivilTwilight)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if morning <= prev || evening <= prev {
+			t.Errorf("Expected duration to grow with latitude %f, got: %f, %f", lat, morning, evening)
+		}
+		if !mathutils.AlmostEqual(morning, evening, 1) {
+			t.Errorf("Expected equal morning and evening twilight at equinox, got: %f, %f", morning, evening)
+		}
+		prev = morning
+	}
+	// about 22 minutes at the equator
+	if morning, _, _ := TwilightDuration(jd, _GREENWICH_LNG, 0, CivilTwilight); !mathutils.AlmostEqual(morning, 22, 2) {
+		t.Errorf("Expected: %f, got: %f", 22.0, morning)
+	}
+	// blue hour is shorter than civil twilight
+	blue, _, err := TwilightDuration(jd, _GREENWICH_LNG, _GREENWICH_LAT, BlueHour)
+	civil, _, _ := TwilightDuration(jd, _GREENWICH_LNG, _GREENWICH_LAT, CivilTwilight)
+	if err != nil || blue <= 0 || blue >= civil {
+		t.Errorf("Unexpected blue hour duration: %f, error: %v", blue, err)
+	}
+	// white nights: astronomical twilight never ends in Greenwich at summer solstice
+	jd = julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 6, Day: 21})
+	if _, _, err := TwilightDuration(jd, _GREENWICH_LNG, _GREENWICH_LAT, AstronomicalTwilight); err != ErrAlwaysAbove {
+		t.Errorf("Expected ErrAlwaysAbove, got: %v", err)
+	}
+}
+
+func TestRiseSetRange(t *testing.T) {
+	// Tromso, polar night begins in late November
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 11, Day: 20})
+	got := RiseSetRange(jd, 14, 18.96, 69.65)
+	if len(got) != 14 {
+		t.Fatalf("Expected 14 days, got: %d", len(got))
+	}
+	if got[0].Status != NormalDay {
+		t.Errorf("Expected the Sun to rise on the first day, got status: %d", got[0].Status)
+	}
+	if got[13].Status != PolarNight {
+		t.Errorf("Expected polar night on the last day, got status: %d", got[13].Status)
+	}
+	for i, ev := range got {
+		if !mathutils.AlmostEqual(ev.Date, jd+float64(i), 1e-9) {
+			t.Errorf("Expected date: %f, got: %f", jd+float64(i), ev.Date)
+		}
+		if i > 0 && ev.Status == NormalDay && got[i-1].Status == PolarNight {
+			t.Errorf("Unexpected sunrise after polar night began, day %d", i)
+		}
+		if ev.Status == NormalDay && (ev.Rise >= ev.Set || ev.Rise < ev.Date) {
+			t.Errorf("Unexpected rise: %f and set: %f for date: %f", ev.Rise, ev.Set, ev.Date)
+		}
+	}
+}
+
+func TestRiseSetLocal(t *testing.T) {
+	// Daylight saving time in the UK begins on 2024 March 31
+	loc, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	before := time.Date(2024, 3, 30, 12, 0, 0, 0, loc)
+	after := time.Date(2024, 3, 31, 12, 0, 0, 0, loc)
+	rise1, set1, err := RiseSetLocal(before, _GREENWICH_LNG, _GREENWICH_LAT, loc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rise2, set2, err := RiseSetLocal(after, _GREENWICH_LNG, _GREENWICH_LAT, loc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// 05:44 GMT and 06:42 BST
+	if rise1.Hour() != 5 || rise2.Hour() != 6 {
+		t.Errorf("Expected local sunrise hours 5 and 6, got: %s and %s", rise1, rise2)
+	}
+	// 18:33 GMT and 19:35 BST
+	if set1.Hour() != 18 || set2.Hour() != 19 {
+		t.Errorf("Expected local sunset hours 18 and 19, got: %s and %s", set1, set2)
+	}
+	// in absolute time the sunrise is about 2 minutes earlier
+	if d := rise2.Sub(rise1) - 24*time.Hour; d > -time.Minute || d < -3*time.Minute {
+		t.Errorf("Unexpected shift of sunrise: %s", d)
+	}
+	if rise1.Location() != loc {
+		t.Errorf("Expected location: %s, got: %s", loc, rise1.Location())
+	}
+}
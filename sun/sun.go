@@ -3,6 +3,7 @@ package sun
 import (
 	"math"
 
+	"github.com/skrushinsky/kepler/constants"
 	"github.com/skrushinsky/kepler/core"
 	"github.com/skrushinsky/scaliger/julian"
 	"github.com/skrushinsky/scaliger/mathutils"
@@ -10,12 +11,26 @@ import (
 
 const ABERRATION = 5.69e-3 // aberration in degrees
 
+// Custom correction of the Sun's true geocentric longitude, arc-degrees,
+// and of the Sun-Earth distance, A.U., for t, Julian centuries since 1900 Jan, 0.5.
+// It allows experimenting with additional perturbation terms.
+type PerturbationFunc func(t float64) (dLon, dRad float64)
+
 // Controls type of the result.
+//
+// With nutation given, [Apparent] returns apparent longitude, referred to the true equinox
+// of date; without it, to the mean equinox. Light-time and aberration may be omitted
+// separately. With both omitted, nutation gives the "true" Sun for the true equinox,
+// and without nutation the result is the geometric longitude of [TrueGeocentric].
 type ApparentSunOptions struct {
+	// Custom perturbations, applied in the given order after the built-in ones
+	Perturbations []PerturbationFunc
 	// nutation in longitude, degrees
 	dpsi float64
 	// ignore light-time travel correction?
 	ignoreLightTravel bool
+	// ignore aberration?
+	ignoreAberration bool
 	// Mean Longitude of the Sun, degrees
 	meanLongitude float64
 	// Mean Anomaly of the Sun, degrees
@@ -79,15 +94,129 @@ func TrueGeocentric(t, ms, ls float64) (lsn float64, rsn float64) {
 
 // Find apparent geocentric ecliptical longitude of the Sun.
 // See [ApparentSunOptions] for details.
+//
+// Corrections are summed in the following order: built-in perturbations of [TrueGeocentric],
+// custom perturbations of the options, nutation, aberration and light-time.
 // All angles in arc-degrees.
 func Apparent(jd float64, options ApparentSunOptions) core.EclipticPosition {
 	t := (jd - julian.J1900) / julian.DAYS_PER_CENT
 	lsn, rsn := TrueGeocentric(t, options.meanAnomaly, options.meanLongitude)
+	for _, f := range options.Perturbations {
+		dl, dr := f(t)
+		lsn += dl
+		rsn += dr
+	}
 	lsn += options.dpsi // correct for nutation
-	lsn -= ABERRATION   // correct for aberration
+	if !options.ignoreAberration {
+		lsn -= ABERRATION // correct for aberration
+	}
 	if !options.ignoreLightTravel {
 		dt := 1.365 * rsn     // seconds
 		lsn -= dt * 15 / 3600 // convert to degrees and substract
 	}
 	return core.EclipticPosition{Lambda: lsn, Delta: rsn}
 }
+
+// True geocentric longitude of the Sun and the Sun-Earth distance for jd, Standard Julian Date.
+func geocentric(jd float64) (lsn float64, rsn float64) {
+	t := (jd - julian.J1900) / julian.DAYS_PER_CENT
+	return TrueGeocentric(t, MeanAnomaly(t), MeanLongitude(t))
+}
+
+// Rate of change of the Sun-Earth distance, A.U. per day, for jd, Standard Julian Date.
+// Positive value means that the distance grows.
+//
+// The rate is found by numeric differentiation of the radius vector returned
+// by [TrueGeocentric]. It vanishes at perihelion and aphelion and reaches its
+// extremes (about ±2.9e-4 A.U./day) roughly midway between them.
+func RadiusVectorRate(jd float64) float64 {
+	const h = 0.5 // step, days
+	_, r1 := geocentric(jd - h)
+	_, r2 := geocentric(jd + h)
+	return (r2 - r1) / (2 * h)
+}
+
+// Daily motion of the Sun in longitude, arc-degrees per day, for jd, Standard Julian Date.
+// Like [RadiusVectorRate], it is found by numeric differentiation. The motion is fastest
+// at perihelion (about 1.019 degree) and slowest at aphelion (about 0.953 degree).
+func DailyMotion(jd float64) float64 {
+	const h = 0.5 // step, days
+	l1, _ := geocentric(jd - h)
+	l2, _ := geocentric(jd + h)
+	return (mathutils.ReduceDeg(l2-l1+180) - 180) / (2 * h)
+}
+
+// Apparent position of the Sun for a Besselian epoch, e.g. 1950.0 for B1950.0,
+// referred to the true equinox of date. See [core.BesselianToJulian] and [Position].
+//
+// Besselian year begins when the Sun's mean longitude is 280 degrees,
+// so at integer epochs the longitude is close to this value.
+func ApparentAtBesselian(besselianYear float64) core.EclipticPosition {
+	return Position(core.BesselianToJulian(besselianYear), core.PrecisionHigh)
+}
+
+// Apparent position of the Sun for jd, Julian Date on a clock which is behind
+// Dynamical Time by deltaT, seconds, e.g. Universal Time with its Delta T.
+//
+// The theory, like [Apparent] and [Position], expects Dynamical Time, and the library
+// has no built-in model of Delta T, so that the offset is entirely up to the caller.
+// The result is [Position] with [core.PrecisionHigh] for jd + deltaT / 86400,
+// i.e. [Apparent] with options of [OptionsWithNutation] for that moment.
+// The Sun moves by about 0.04" per second of time.
+func ApparentAt(jd, deltaT float64) core.EclipticPosition {
+	return Position(jd+deltaT/86400, core.PrecisionHigh)
+}
+
+// Moments, Standard Julian Dates, of the fastest and the slowest motion of the Sun
+// in a given year, which are close to the Earth's perihelion (early January)
+// and aphelion (early July).
+//
+// Near the apsides the motion changes very slowly, so that monthly perturbation
+// by the Moon would shift extremes of [DailyMotion] by more than a week.
+// Therefore the motion is averaged over a synodic month, which removes the lunar term.
+// Extremes of the averaged motion are found by scanning the first quarter
+// and the middle of the year day by day, see [core.FindExtremum].
+func TimeOfExtremeMotion(year int) (perihelionDate, aphelionDate float64) {
+	const h = 29.530589 / 2 // half of the synodic month, days
+	motion := func(jd float64) float64 {
+		l1, _ := geocentric(jd - h)
+		l2, _ := geocentric(jd + h)
+		return (mathutils.ReduceDeg(l2-l1+180) - 180) / (2 * h)
+	}
+	start := julian.CivilToJulian(julian.CivilDate{Year: year, Month: 1, Day: 1})
+	// perihelion falls on January 2-5, aphelion on July 3-7
+	perihelionDate, _ = core.FindExtremum(motion, start, start+90, 1, true)
+	aphelionDate, _ = core.FindExtremum(motion, start+120, start+240, 1, false)
+	return
+}
+
+// Apparent angular diameter of the Sun, arc-seconds, for jd, Standard Julian Date.
+func AngularDiameter(jd float64) float64 {
+	_, rsn := geocentric(jd)
+	return core.AngularDiameter(constants.SUN_RADIUS, rsn*constants.AU)
+}
+
+// Apparent geocentric ecliptic position of the Sun for jd, Standard Julian Date,
+// with a given level of precision. All angles in arc-degrees.
+//
+//   - [core.PrecisionLow]: mean orbit with two terms of the equation of the center,
+//     no perturbations and no nutation; error is below 0.02 degree.
+//   - [core.PrecisionMedium]: [TrueGeocentric] corrected for aberration, mean equinox of date;
+//     differs from the high precision result by nutation, i.e. up to 0.006 degree.
+//   - [core.PrecisionHigh]: same as [Apparent] with nutation in longitude.
+func Position(jd float64, precision core.Precision) core.EclipticPosition {
+	t := (jd - julian.J1900) / julian.DAYS_PER_CENT
+	ms := MeanAnomaly(t)
+	ls := MeanLongitude(t)
+	switch precision {
+	case core.PrecisionLow:
+		m := mathutils.Radians(ms)
+		c := 1.914600*sin(m) + 0.019993*sin(2*m)
+		r := 1.000140 - 0.016710*cos(m) - 0.000140*cos(2*m)
+		return core.EclipticPosition{Lambda: mathutils.ReduceDeg(ls + c - ABERRATION), Delta: r}
+	case core.PrecisionMedium:
+		return Apparent(jd, ApparentSunOptions{ignoreLightTravel: true, meanAnomaly: ms, meanLongitude: ls})
+	default:
+		return Apparent(jd, ApparentSunOptions{dpsi: core.ComputeNutation(jd).Dpsi, ignoreLightTravel: true, meanAnomaly: ms, meanLongitude: ls})
+	}
+}
@@ -3,6 +3,8 @@ package sun
 import (
 	"testing"
 
+	"github.com/skrushinsky/kepler/core"
+	"github.com/skrushinsky/kepler/internal/golden"
 	"github.com/skrushinsky/scaliger/julian"
 	"github.com/skrushinsky/scaliger/mathutils"
 	"github.com/skrushinsky/scaliger/nutequ"
@@ -80,3 +82,186 @@ func TestApparent(t *testing.T) {
 		}
 	}
 }
+
+func TestApparentIgnoreAberration(t *testing.T) {
+	for _, test := range cases {
+		tperiod := test.djd / julian.DAYS_PER_CENT
+		jd := test.djd + julian.J1900
+		dpsi, _ := nutequ.Nutation(jd)
+		ms, ls := MeanAnomaly(tperiod), MeanLongitude(tperiod)
+		opts := ApparentSunOptions{
+			meanAnomaly:       ms,
+			meanLongitude:     ls,
+			ignoreLightTravel: true,
+			ignoreAberration:  true,
+			dpsi:              dpsi,
+		}
+		lsn, rsn := TrueGeocentric(tperiod, ms, ls)
+		got := Apparent(jd, opts)
+		if !mathutils.AlmostEqual(got.Lambda, lsn+dpsi, 1e-12) {
+			t.Errorf("Expected: %f, got: %f", lsn+dpsi, got.Lambda)
+		}
+		if !mathutils.AlmostEqual(got.Delta, rsn, 1e-12) {
+			t.Errorf("Expected: %f, got: %f", rsn, got.Delta)
+		}
+		opts.ignoreAberration = false
+		if exp := Apparent(jd, opts).Lambda + ABERRATION; !mathutils.AlmostEqual(got.Lambda, exp, 1e-12) {
+			t.Errorf("Expected: %f, got: %f", exp, got.Lambda)
+		}
+	}
+}
+
+func TestApparentPerturbations(t *testing.T) {
+	zero := func(t float64) (float64, float64) { return 0, 0 }
+	shift := func(t float64) (float64, float64) { return 0.01, 1e-4 }
+	for _, test := range cases {
+		tperiod := test.djd / julian.DAYS_PER_CENT
+		jd := test.djd + julian.J1900
+		opts := ApparentSunOptions{
+			meanAnomaly:       MeanAnomaly(tperiod),
+			meanLongitude:     MeanLongitude(tperiod),
+			ignoreLightTravel: true,
+		}
+		exp := Apparent(jd, opts)
+		opts.Perturbations = []PerturbationFunc{zero}
+		if got := Apparent(jd, opts); got != exp {
+			t.Errorf("Expected: %v, got: %v", exp, got)
+		}
+		opts.Perturbations = []PerturbationFunc{shift, zero, shift}
+		got := Apparent(jd, opts)
+		if !mathutils.AlmostEqual(got.Lambda, exp.Lambda+0.02, 1e-9) {
+			t.Errorf("Expected: %f, got: %f", exp.Lambda+0.02, got.Lambda)
+		}
+		if !mathutils.AlmostEqual(got.Delta, exp.Delta+2e-4, 1e-12) {
+			t.Errorf("Expected: %f, got: %f", exp.Delta+2e-4, got.Delta)
+		}
+	}
+}
+
+func TestRadiusVectorRate(t *testing.T) {
+	type _RateCase struct {
+		date julian.CivilDate
+		min  float64
+		max  float64
+	}
+	rateCases := [...]_RateCase{
+		// perihelion, 2024 Jan 3, 00:39 UT
+		{date: julian.CivilDate{Year: 2024, Month: 1, Day: 3.03}, min: -2e-5, max: 2e-5},
+		// aphelion, 2024 Jul 5, 05:06 UT
+		{date: julian.CivilDate{Year: 2024, Month: 7, Day: 5.21}, min: -2e-5, max: 2e-5},
+		// Earth recedes from the Sun
+		{date: julian.CivilDate{Year: 2024, Month: 4, Day: 4}, min: 2.6e-4, max: 3e-4},
+		// Earth approaches the Sun
+		{date: julian.CivilDate{Year: 2024, Month: 10, Day: 4}, min: -3e-4, max: -2.6e-4},
+	}
+	for _, test := range rateCases {
+		got := RadiusVectorRate(julian.CivilToJulian(test.date))
+		if got < test.min || got > test.max {
+			t.Errorf("Expected rate in range %e..%e, got: %e", test.min, test.max, got)
+		}
+	}
+}
+
+func TestDailyMotion(t *testing.T) {
+	peri := DailyMotion(julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 3}))
+	aphe := DailyMotion(julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 7, Day: 5}))
+	if !mathutils.AlmostEqual(peri, 1.0194, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", 1.0194, peri)
+	}
+	if !mathutils.AlmostEqual(aphe, 0.9532, 1e-3) {
+		t.Errorf("Expected: %f, got: %f", 0.9532, aphe)
+	}
+	// longitude passes 0 at vernal equinox
+	if got := DailyMotion(julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 3, Day: 20.13})); got < 0.95 || got > 1.02 {
+		t.Errorf("Unexpected motion at equinox: %f", got)
+	}
+}
+
+func TestApparentAt(t *testing.T) {
+	jd := julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 3})
+	exp := Apparent(jd, OptionsWithNutation(jd, core.ComputeNutation(jd)))
+	if got := ApparentAt(jd, 0); got != exp {
+		t.Errorf("Expected: %v, got: %v", exp, got)
+	}
+	// in an hour the Sun moves by 1/24 of its daily motion
+	got := ApparentAt(jd, 3600)
+	if d := (got.Lambda - exp.Lambda) * 24; !mathutils.AlmostEqual(d, DailyMotion(jd), 1e-4) {
+		t.Errorf("Expected: %f, got: %f", DailyMotion(jd), d)
+	}
+}
+
+func TestApparentAtBesselian(t *testing.T) {
+	got := ApparentAtBesselian(1950)
+	exp := Position(2433282.4235, core.PrecisionHigh)
+	// B1950.0 is given to 1e-4 day, the Sun moves by 1e-4 degree during this time
+	if !mathutils.AlmostEqual(got.Lambda, exp.Lambda, 1e-4) {
+		t.Errorf("Expected: %f, got: %f", exp.Lambda, got.Lambda)
+	}
+	if !mathutils.AlmostEqual(got.Lambda, 280, 0.1) {
+		t.Errorf("Expected: %f, got: %f", 280.0, got.Lambda)
+	}
+}
+
+func TestTimeOfExtremeMotion(t *testing.T) {
+	for year := 2015; year <= 2030; year++ {
+		peri, aphe := TimeOfExtremeMotion(year)
+		pd := julian.JulianToCivil(peri)
+		ad := julian.JulianToCivil(aphe)
+		if pd.Month != 1 || pd.Day > 8 {
+			t.Errorf("%d: expected fastest motion in early January, got: %s", year, julian.JulianToDateString(peri))
+		}
+		if ad.Month != 7 || ad.Day > 8 {
+			t.Errorf("%d: expected slowest motion in early July, got: %s", year, julian.JulianToDateString(aphe))
+		}
+	}
+}
+
+func TestAngularDiameter(t *testing.T) {
+	// perihelion, 2024 Jan 3 and aphelion, 2024 Jul 5
+	peri := AngularDiameter(julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 1, Day: 3}))
+	aphe := AngularDiameter(julian.CivilToJulian(julian.CivilDate{Year: 2024, Month: 7, Day: 5}))
+	if !mathutils.AlmostEqual(peri, 1951.5, 1) {
+		t.Errorf("Expected: %f, got: %f", 1951.5, peri)
+	}
+	if !mathutils.AlmostEqual(aphe, 1887.1, 1) {
+		t.Errorf("Expected: %f, got: %f", 1887.1, aphe)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	for _, test := range cases {
+		jd := test.djd + julian.J1900
+		high := Position(jd, core.PrecisionHigh)
+		if !mathutils.AlmostEqual(high.Lambda, test.ap, _DELTA) {
+			t.Errorf("Expected: %f, got: %f", test.ap, high.Lambda)
+		}
+		for _, p := range []core.Precision{core.PrecisionLow, core.PrecisionMedium} {
+			pos := Position(jd, p)
+			if d := mathutils.ReduceDeg(pos.Lambda-high.Lambda+180) - 180; !mathutils.AlmostEqual(d, 0, 0.02) {
+				t.Errorf("Precision %d: expected: %f, got: %f", p, high.Lambda, pos.Lambda)
+			}
+			if !mathutils.AlmostEqual(pos.Delta, high.Delta, 1e-4) {
+				t.Errorf("Precision %d: expected: %f, got: %f", p, high.Delta, pos.Delta)
+			}
+		}
+	}
+}
+
+func BenchmarkPositionLow(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Position(julian.J2000+float64(i%36525), core.PrecisionLow)
+	}
+}
+
+func BenchmarkPositionHigh(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Position(julian.J2000+float64(i%36525), core.PrecisionHigh)
+	}
+}
+
+func TestGoldenPosition(t *testing.T) {
+	golden.Check(t, "testdata/position.golden", 1e-9, func(jd float64) []float64 {
+		pos := Position(jd, core.PrecisionHigh)
+		return []float64{pos.Lambda, pos.Delta}
+	})
+}